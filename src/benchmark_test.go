@@ -0,0 +1,218 @@
+/**
+ * go-genetic-ml: Benchmarks
+ *
+ * Timings and allocation counts of the population's hot paths
+ *
+ * @copyright Copyright (C) 2018 Daniel J. Wilson <hello@danw.io>
+ * @license GNU GPL v3.0 - See LICENSE
+ */
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+/**
+ * Population Generate Benchmark
+ * Breeds generations of 1000 entities from one mating pool, so that the DNA
+ * pool's recycling shows in the allocations per generation
+ */
+func BenchmarkPopulationGenerate(b *testing.B) {
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 1000, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Children are not assessed, so selecting from them would leave the pool empty
+	populationNaturalSelection(population)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := populationGenerate(population); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+/**
+ * DNA Mutate Benchmark
+ * Mutates 1000 genes at a rate of 0.01, which being in place should not allocate
+ */
+func BenchmarkDnaMutate(b *testing.B) {
+	var rng = NewPRNG(42)
+	var entity DNA
+	dnaCreate(rng, &entity, 1000, PrintableASCII)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dnaMutate(rng, &entity, 0.01, PrintableASCII)
+	}
+}
+
+/**
+ * Natural Selection Benchmark
+ * Fills the mating pool of 1000 entities, which being allocated up front should
+ * take a single allocation
+ */
+func BenchmarkNaturalSelection(b *testing.B) {
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 1000, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		populationNaturalSelection(population)
+	}
+}
+
+/**
+ * Get Best Benchmark
+ * Gets the best phrase of a population of 10000 entities 100 times, either from
+ * the cached best entity, or rescanning the population for every call
+ */
+func benchmarkGetBest(b *testing.B, cached bool) {
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 10000, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 100; j++ {
+			population.CacheDirty = !cached
+			populationGetBest(population)
+		}
+	}
+}
+
+/**
+ * Cached Get Best Benchmark
+ * Gets the best phrase 100 times from the cache
+ */
+func BenchmarkGetBestCached(b *testing.B) {
+	benchmarkGetBest(b, true)
+}
+
+/**
+ * Uncached Get Best Benchmark
+ * Gets the best phrase 100 times, rescanning the population each time
+ */
+func BenchmarkGetBestUncached(b *testing.B) {
+	benchmarkGetBest(b, false)
+}
+
+/**
+ * Bool Crossover
+ * Crosses over binary genes held one to a bool, as PackedBinaryDNACrossover
+ * does, as the unpacked baseline for the packed crossover benchmarks
+ */
+func boolCrossover(rng *PRNG, partnerA []bool, partnerB []bool) []bool {
+	var child = make([]bool, len(partnerA))
+	if len(partnerA) == 0 {
+		return child
+	}
+
+	var midpoint = rng.Int(0, len(partnerA))
+	copy(child[:midpoint+1], partnerB[:midpoint+1])
+	copy(child[midpoint+1:], partnerA[midpoint+1:])
+
+	return child
+}
+
+/**
+ * Bool Crossover Benchmark: N
+ * Crosses over a pair of n genes held one to a bool
+ */
+func benchmarkBoolCrossoverN(b *testing.B, n int) {
+	var rng = NewPRNG(42)
+	var partnerA, partnerB = make([]bool, n), make([]bool, n)
+	for i := 0; i < n; i++ {
+		partnerA[i], partnerB[i] = rng.Int(0, 2) == 1, rng.Int(0, 2) == 1
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		boolCrossover(rng, partnerA, partnerB)
+	}
+}
+
+/**
+ * Packed Crossover Benchmark: N
+ * Crosses over a pair of n genes packed 64 to a word
+ */
+func benchmarkPackedCrossoverN(b *testing.B, n int) {
+	var rng = NewPRNG(42)
+	var partnerA, partnerB = PackedBinaryDNACreate(rng, n), PackedBinaryDNACreate(rng, n)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := PackedBinaryDNACrossover(rng, &partnerA, &partnerB); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+/**
+ * Crossover Benchmark Lengths
+ * The gene counts the bool and packed crossovers are compared at
+ */
+var crossoverBenchmarkLengths = []int{64, 256, 1024}
+
+/**
+ * Bool Crossover Benchmark
+ * Crosses over pairs of bool genes of each benchmarked length
+ */
+func BenchmarkBoolCrossover(b *testing.B) {
+	for _, n := range crossoverBenchmarkLengths {
+		b.Run(strconv.Itoa(n), func(b *testing.B) { benchmarkBoolCrossoverN(b, n) })
+	}
+}
+
+/**
+ * Packed Crossover Benchmark
+ * Crosses over pairs of packed genes of each benchmarked length
+ */
+func BenchmarkPackedCrossover(b *testing.B) {
+	for _, n := range crossoverBenchmarkLengths {
+		b.Run(strconv.Itoa(n), func(b *testing.B) { benchmarkPackedCrossoverN(b, n) })
+	}
+}
+
+/**
+ * Float Population Generate Benchmark
+ * Breeds generations of 100 entities of the given 2D float benchmark
+ */
+func benchmarkFloatGenerate(b *testing.B, fitness FloatFitnessFunc, bound [2]float64) {
+	var population = FloatPopulation{bounds: FloatBounds(2, bound), fitnessFunc: fitness, mutationRate: 0.1, sigma: 0.1, alpha: 0.5, rng: NewPRNG(42)}
+	floatPopulationSetup(&population, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		floatPopulationGenerate(&population)
+	}
+}
+
+/**
+ * Rastrigin 2D Benchmark
+ * Breeds generations of the 2D Rastrigin function
+ */
+func BenchmarkRastrigin2D(b *testing.B) {
+	benchmarkFloatGenerate(b, RastriginFitness(2), RastriginBounds)
+}
+
+/**
+ * Rosenbrock 2D Benchmark
+ * Breeds generations of the 2D Rosenbrock function
+ */
+func BenchmarkRosenbrock2D(b *testing.B) {
+	benchmarkFloatGenerate(b, RosenbrockFitness(2), RosenbrockBounds)
+}
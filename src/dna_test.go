@@ -0,0 +1,176 @@
+/**
+ * go-genetic-ml: DNA Tests
+ *
+ * Property and fuzz tests of the DNA operations against randomly generated DNA
+ *
+ * @copyright Copyright (C) 2018 Daniel J. Wilson <hello@danw.io>
+ * @license GNU GPL v3.0 - See LICENSE
+ */
+package main
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+/**
+ * DNA: Generate
+ * Implements quick.Generator, creating random printable ASCII DNA of up to size
+ * genes for property tests
+ */
+func (d DNA) Generate(rand *rand.Rand, size int) reflect.Value {
+	var genes = make([]rune, rand.Intn(size+1))
+	for i := range genes {
+		genes[i] = PrintableASCII.Runes[rand.Intn(len(PrintableASCII.Runes))]
+	}
+
+	return reflect.ValueOf(DNA{genes: genes})
+}
+
+/**
+ * Crossover Properties
+ * A child has as many genes as its parents
+ */
+func TestCrossoverProperties(t *testing.T) {
+	var rng = NewPRNG(42)
+
+	var sameLength = func(a DNA) bool {
+		var b = DNA{}
+		dnaCreate(rng, &b, len(a.genes), PrintableASCII)
+		var child, err = dnaCrossover(rng, &a, &b)
+		return err == nil && len(child.genes) == len(a.genes)
+	}
+
+	if err := quick.Check(sameLength, nil); err != nil {
+		t.Error("crossover child length differs from its parents':", err)
+	}
+}
+
+/**
+ * Mutation Properties
+ * Mutation at rate 0 leaves the genes unchanged, and at any rate keeps them
+ * within the alphabet's [32, 128)
+ */
+func TestMutateProperties(t *testing.T) {
+	var rng = NewPRNG(42)
+
+	var unchanged = func(a DNA) bool {
+		var before = dnaExtractPhrase(&a)
+		dnaMutate(rng, &a, 0, PrintableASCII)
+		return dnaExtractPhrase(&a) == before
+	}
+
+	if err := quick.Check(unchanged, nil); err != nil {
+		t.Error("mutation at rate 0 changed the genes:", err)
+	}
+
+	var inRange = func(a DNA) bool {
+		dnaMutate(rng, &a, 0.5, PrintableASCII)
+		for _, gene := range a.genes {
+			if gene < 32 || gene >= 128 {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(inRange, nil); err != nil {
+		t.Error("mutation left a gene outside [32, 128):", err)
+	}
+}
+
+/**
+ * Fitness Properties
+ * Fitness against a target of the same length is within [0, 1]
+ */
+func TestFitnessProperties(t *testing.T) {
+	var rng = NewPRNG(42)
+
+	var inRange = func(a DNA) bool {
+		if len(a.genes) == 0 {
+			return true
+		}
+
+		var target = DNA{}
+		dnaCreate(rng, &target, len(a.genes), PrintableASCII)
+		dnaAssessFitness(&a, dnaExtractPhrase(&target))
+		return a.fitness >= 0 && a.fitness <= 1
+	}
+
+	if err := quick.Check(inRange, nil); err != nil {
+		t.Error("fitness was outside [0, 1]:", err)
+	}
+}
+
+/**
+ * Fuzz Crossover
+ * Crosses over random parents of arbitrary lengths, seeded with the boundary
+ * cases (no genes, a single gene and the maximum length). Parents of equal
+ * length breed a child of that length whose every gene comes from one of them;
+ * parents of differing length are rejected with ErrGeneLengthMismatch.
+ */
+func FuzzDnaCrossover(f *testing.F) {
+	f.Add(int64(42), uint16(0), uint16(0))
+	f.Add(int64(42), uint16(1), uint16(1))
+	f.Add(int64(42), uint16(math.MaxUint16), uint16(math.MaxUint16))
+	f.Add(int64(42), uint16(3), uint16(4))
+
+	f.Fuzz(func(t *testing.T, seed int64, lengthA uint16, lengthB uint16) {
+		var rng = NewPRNG(seed)
+		var partnerA, partnerB = DNA{}, DNA{}
+		dnaCreate(rng, &partnerA, int(lengthA), PrintableASCII)
+		dnaCreate(rng, &partnerB, int(lengthB), PrintableASCII)
+
+		var child, err = dnaCrossover(rng, &partnerA, &partnerB)
+		if lengthA != lengthB {
+			if _, ok := err.(ErrGeneLengthMismatch); !ok {
+				t.Fatal("expected ErrGeneLengthMismatch crossing", lengthA, "with", lengthB, "genes, got", err)
+			}
+			return
+		}
+
+		if err != nil {
+			t.Fatal("crossover of equal length parents failed:", err)
+		}
+		if len(child.genes) != int(lengthA) {
+			t.Fatal("child has", len(child.genes), "genes, its parents", lengthA)
+		}
+		for i, gene := range child.genes {
+			if gene != partnerA.genes[i] && gene != partnerB.genes[i] {
+				t.Fatal("child gene", i, "came from neither parent")
+			}
+		}
+	})
+}
+
+/**
+ * Fuzz Mutation
+ * Mutates random genes of arbitrary length at an arbitrary rate, seeded with
+ * the boundary cases (no genes, a single gene and the maximum length). The
+ * genes keep their length and stay within the alphabet's [32, 128).
+ */
+func FuzzDnaMutate(f *testing.F) {
+	f.Add(int64(42), uint16(0), float32(1.0))
+	f.Add(int64(42), uint16(1), float32(1.0))
+	f.Add(int64(42), uint16(math.MaxUint16), float32(1.0))
+	f.Add(int64(42), uint16(13), float32(0.5))
+
+	f.Fuzz(func(t *testing.T, seed int64, length uint16, rate float32) {
+		var rng = NewPRNG(seed)
+		var entity = DNA{}
+		dnaCreate(rng, &entity, int(length), PrintableASCII)
+
+		dnaMutate(rng, &entity, rate, PrintableASCII)
+		if len(entity.genes) != int(length) {
+			t.Fatal("mutation changed the length from", length, "to", len(entity.genes))
+		}
+		for i, gene := range entity.genes {
+			if gene < 32 || gene >= 128 {
+				t.Fatal("mutation left gene", i, "outside [32, 128):", gene)
+			}
+		}
+	})
+}
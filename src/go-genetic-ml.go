@@ -28,9 +28,22 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/bits"
 	"math/rand"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -46,358 +59,9846 @@ var (
 
 	// Mutation Rate
 	mutrate float32 = 0.01
+
+	// Generation Mode (Generational or SteadyState)
+	generationMode = Generational
+
+	// Steady-State Replacements (worst entities replaced per generation)
+	replacements = 1
+
+	// Generational Gap (fraction of the population replaced per generation)
+	generationalGap float32 = 1.0
+
+	// Crossover Rate (probability a mating pair undergoes crossover)
+	crossoverRate float32 = 1.0
+
+	// Elite Count (fittest entities carried over unchanged each generation)
+	eliteCount = 0
+)
+
+/**
+ * PRNG
+ * A source of random numbers for the DNA and population operations. Seeded with
+ * NewPRNG, a PRNG repeats the same numbers on every run, but must only be used
+ * by one goroutine at a time. GlobalPRNG (or a nil PRNG) draws from math/rand's
+ * shared source instead, which is safe for concurrent use.
+ */
+type PRNG struct {
+	src *rand.Rand
+}
+
+/**
+ * Global PRNG
+ * The PRNG used wherever no other is given
+ */
+var GlobalPRNG = &PRNG{}
+
+/**
+ * New PRNG
+ * Creates a PRNG repeatably seeded with the given seed
+ */
+func NewPRNG(seed int64) *PRNG {
+	return &PRNG{src: rand.New(rand.NewSource(seed))}
+}
+
+/**
+ * Generation Mode
+ * Determines how each new generation replaces the entities of the last
+ */
+type GenerationMode int
+
+const (
+	// Generational: the whole population is replaced each generation
+	Generational GenerationMode = iota
+
+	// SteadyState: only the worst few entities are replaced each generation
+	SteadyState
+
+	// TournamentReplacementMode: each generation, one child per entity replaces
+	// the loser of a tournament if it is fitter (see TournamentReplacement)
+	TournamentReplacementMode
+
+	// MuPlusLambdaMode: each generation, Lambda children compete with their
+	// parents for a place in the population (see MuPlusLambdaGenerate)
+	MuPlusLambdaMode
+
+	// MuCommaLambdaMode: each generation, the population is replaced by the
+	// fittest of Lambda children (see MuCommaLambdaGenerate)
+	MuCommaLambdaMode
+)
+
+/**
+ * Reproduction Mode
+ * Determines how an entity reproduces when picked as the first parent
+ */
+type ReproductionMode byte
+
+const (
+	// CrossoverMode: the child is crossed over with a second parent
+	CrossoverMode ReproductionMode = iota
+
+	// ClonalMode: the child is a mutated copy of the parent alone
+	ClonalMode
+)
+
+/**
+ * Config
+ * Holds the settings a population is evolved with
+ */
+type Config struct {
+	Target           string
+	MaxPop           int
+	MutationRate     float32
+	GenerationMode   GenerationMode
+	Replacements     int
+	GenerationalGap  float32
+	DynamicTargetFn  DynamicTargetFn
+	CrossoverRate    float32
+	RepairFn         func(*DNA) *DNA
+	ConstraintFn     func(dna *DNA) bool
+	PenaltyFn        func(dna *DNA) float32
+	PenaltyWeight    float32
+	PenaltySchedule  PenaltySchedule
+	Selector         Selector
+	Alphabet         Alphabet
+	FitnessFunc      FitnessFunc
+	EliteCount       int
+	SpeciesDistance  int
+	Choosiness       float32
+	Verbose          bool
+	PhraseLimit      int
+	RestartThreshold int
+	OnGenerationEnd  func(population *Population)
+	AgingPenalty     float32
+	LaMarckianMode   bool
+	LocalSearchSteps int
+	FitnessCache     *FitnessCache
+	ModeMutationRate float32
+	TournamentSize   int
+	Seed             int64
+	Lambda           int
+	Initialization   InitializationStrategy
+	EventLog         *EventLog
+	Evaluator        *AsyncEvaluator
+	AuditMutations   bool
+}
+
+/**
+ * Fitness Function
+ * A custom fitness assessment, returning the fitness (0.0 - 1.0) of the given
+ * dna. Used in place of matching the genes against the target.
+ */
+type FitnessFunc func(dna *DNA) float32
+
+/**
+ * Float Fitness Function
+ * A custom fitness assessment for real-valued DNA, returning the fitness
+ * (0.0 - 1.0) of the given dna
+ */
+type FloatFitnessFunc func(dna *FloatDNA) float32
+
+/**
+ * Dynamic Target Function
+ * Returns the target outcome for the given generation, allowing the target to
+ * change over time (a non-stationary environment)
+ */
+type DynamicTargetFn func(generation int) string
+
+/**
+ * Selector
+ * Performs selection on the population's current entities, filling its mating
+ * pool with the DNA candidates to become parents
+ */
+type Selector interface {
+	Select(population *Population)
+}
+
+/**
+ * Initialization Strategy
+ * Fills the population's empty entities with generation 0
+ */
+type InitializationStrategy interface {
+	Initialize(population *Population)
+}
+
+/**
+ * Random Initialization
+ * The default initialization strategy: every entity of generation 0 is made of
+ * genes picked at random from the alphabet
+ */
+type RandomInitialization struct{}
+
+/**
+ * Partially Deterministic Initialization
+ * Seeds the first HintFraction (0.0 - 1.0) of generation 0 with the given hint
+ * phrases, known good solutions, taken in turn. The rest of the entities are
+ * random. Hints should be as long as the target.
+ */
+type PartiallyDeterministic struct {
+	Hints        []string
+	HintFraction float32
+}
+
+/**
+ * Parents Initialization
+ * Fills generation 0 with mutated children of the given parents, see
+ * NewPopulationFromParents
+ */
+type parentsInitialization struct {
+	parents []DNA
+}
+
+/**
+ * Selector Func
+ * Adapts an ordinary function into a Selector
+ */
+type SelectorFunc func(population *Population)
+
+/**
+ * Fitness Proportionate Selector
+ * The default selector, see populationNaturalSelection
+ */
+type FitnessProportionateSelector struct{}
+
+/**
+ * Tournament Selector
+ * Fills the mating pool with the winners of tournaments between Size randomly
+ * picked entities. The winner is the fittest entity, unless Better is set in
+ * which case it decides whether entity a beats entity b.
+ * Entities are picked without replacement, so none can meet itself, unless
+ * WithReplacement is set.
+ */
+type TournamentSelector struct {
+	Size            int
+	Better          func(a, b *DNA) bool
+	WithReplacement bool
+}
+
+/**
+ * Linear Rank Selector
+ * Fills the mating pool by stochastic universal sampling, with each entity's
+ * chance depending on its fitness rank rather than its fitness. The selective
+ * pressure SP (between 1.0 and 2.0) sets the best entity's expected number of
+ * copies; at 1.0 every entity is equally likely, at 2.0 the worst is never picked.
+ */
+type LinearRankSelector struct {
+	SP float64
+}
+
+/**
+ * Fitness Cache
+ * Remembers the fitness of each gene string evaluated, so that an expensive
+ * fitness function is only run once per distinct genotype. Fitness is cached by
+ * genes alone, so a cache should not be shared between different fitness
+ * functions or targets. Safe for concurrent use.
+ */
+type FitnessCache struct {
+	cache        map[string]float32
+	hits, misses int64
+	mu           sync.Mutex
+}
+
+/**
+ * Async Evaluator
+ * A pool of workers evaluating fitness concurrently, for fitness functions slow
+ * enough (such as simulations) to be worth running side by side. The fitness
+ * function must be safe for concurrent use.
+ */
+type AsyncEvaluator struct {
+	workers int
+	fitness FitnessFunc
+	jobs    chan evalJob
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+}
+
+/**
+ * Evaluation Job
+ * A dna waiting to be evaluated, the function to evaluate it with, and where to
+ * send its fitness
+ */
+type evalJob struct {
+	dna     *DNA
+	fitness FitnessFunc
+	result  chan<- float32
+}
+
+/**
+ * Novelty Archive
+ * Holds the behaviours (descriptions of what an entity did, as vectors) seen so
+ * far in novelty search, against which the novelty of new behaviours is judged.
+ * Once it holds more than Capacity (if above 0) behaviours it is pruned of the
+ * most crowded. K is the number of nearest neighbours considered, 15 if unset.
+ */
+type NoveltyArchive struct {
+	Behaviors [][]float64
+	Capacity  int
+	K         int
+}
+
+/**
+ * Penalty Schedule
+ * Returns the penalty weight to use for the given generation, allowing
+ * constraint violations to be penalised more heavily as evolution progresses
+ */
+type PenaltySchedule func(generation int) float32
+
+/**
+ * Alphabet
+ * The set of runes genes are picked from when created or mutated
+ */
+type Alphabet struct {
+	Runes []rune
+}
+
+/**
+ * Built-in Alphabets
+ */
+var (
+	// Printable ASCII characters (32-127), the default
+	PrintableASCII = Alphabet{runeRange(32, 128)}
+
+	// Lowercase letters a-z
+	LowercaseAlpha = Alphabet{runeRange('a', 'z'+1)}
+
+	// Uppercase letters A-Z
+	UppercaseAlpha = Alphabet{runeRange('A', 'Z'+1)}
+
+	// Letters a-z, A-Z and digits 0-9
+	AlphaNumeric = Alphabet{append(append(runeRange('a', 'z'+1), runeRange('A', 'Z'+1)...), runeRange('0', '9'+1)...)}
+
+	// Nucleotide bases
+	DNA4 = Alphabet{[]rune{'A', 'T', 'G', 'C'}}
+
+	// Nucleotide bases, under their longer name
+	DNA4Alphabet = DNA4
+
+	// Binary digits
+	Binary = Alphabet{[]rune{'0', '1'}}
 )
 
 /**
- * DNA
- * Represents a single entity, there genes (rune slice) and assessed fitness
+ * Empty Mating Pool Error
+ * Returned when a new generation is to be bred from an empty mating pool
+ */
+type ErrEmptyMatingPool struct{}
+
+func (e ErrEmptyMatingPool) Error() string {
+	return "mating pool is empty"
+}
+
+/**
+ * Gene Length Mismatch Error
+ * Returned when two gene sequences (or a gene sequence and its target) which must
+ * be the same length are not
+ */
+type ErrGeneLengthMismatch struct {
+	A, B int
+}
+
+func (e ErrGeneLengthMismatch) Error() string {
+	return fmt.Sprintf("gene length mismatch: %d != %d", e.A, e.B)
+}
+
+/**
+ * Invalid Config Error
+ * Returned when a config field holds an invalid value
+ */
+type ErrInvalidConfig struct {
+	Field  string
+	Reason string
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("invalid config: %s %s", e.Field, e.Reason)
+}
+
+/**
+ * Population Size Mismatch Error
+ * Returned when two populations which must hold the same number of entities
+ * do not
+ */
+type ErrPopulationSizeMismatch struct {
+	A, B int
+}
+
+func (e ErrPopulationSizeMismatch) Error() string {
+	return fmt.Sprintf("population size mismatch: %d != %d", e.A, e.B)
+}
+
+/**
+ * No Feasible Initialization Error
+ * Returned when no randomly created DNA satisfied a constraint within the given
+ * number of attempts
+ */
+type ErrNoFeasibleInitialization struct {
+	Attempts int
+}
+
+func (e ErrNoFeasibleInitialization) Error() string {
+	return fmt.Sprintf("no feasible dna created in %d attempts", e.Attempts)
+}
+
+/**
+ * Gene Range Error
+ * Returned when a range of gene positions [Start, End) lies outside of a gene
+ * sequence of the given Length, or ends before it starts
+ */
+type ErrGeneRange struct {
+	Start, End, Length int
+}
+
+func (e ErrGeneRange) Error() string {
+	return fmt.Sprintf("gene range [%d, %d) out of bounds for length %d", e.Start, e.End, e.Length)
+}
+
+/**
+ * DNA
+ * Represents a single entity, there genes (rune slice) and assessed fitness
+ * Age counts the generations the entity has survived without being replaced.
+ */
+type DNA struct {
+	genes            []rune
+	fitness          float32
+	Age              int
+	ReproductionMode ReproductionMode
+
+	// Unique identity, and those of the parents it was bred from (0 for none)
+	ID        int64
+	ParentIDs [2]int64
+}
+
+/**
+ * DNA IDs
+ * The last ID given to an entity, see dnaNewID
+ */
+var dnaLastID int64
+
+/**
+ * Float DNA
+ * Represents a single entity with real-valued genes (float64 slice), such as
+ * the weights of a neural network, and its assessed fitness
+ */
+type FloatDNA struct {
+	genes   []float64
+	fitness float32
+}
+
+/**
+ * Packed Binary DNA
+ * Represents a single entity with binary genes, packed 64 to a uint64 word so
+ * that each gene uses a single bit of memory
+ */
+type PackedBinaryDNA struct {
+	data    []uint64
+	length  int
+	fitness float32
+}
+
+/**
+ * Float Population
+ * Holds the real-valued entities of a population, the bounds of each gene, and
+ * the settings they are evolved with
+ */
+type FloatPopulation struct {
+	entities     []FloatDNA
+	generations  int
+	bounds       [][2]float64
+	fitnessFunc  FloatFitnessFunc
+	mutationRate float32
+	sigma        float64 // Gaussian mutation standard deviation, as a fraction of the gene's range
+	alpha        float64 // BLX-alpha crossover range extension
+	rng          *PRNG
+}
+
+/**
+ * Diploid DNA
+ * Represents a diploid entity carrying two strands of genes (alleles) and a
+ * dominance table mapping a recessive allele to the dominant allele that
+ * overrides it when both are present at the same locus
+ */
+type DiploidDNA struct {
+	genesA, genesB []rune
+	dominanceTable map[rune]rune
+	fitness        float32
+}
+
+/**
+ * GP Node
+ * A node of an expression tree: an operator applied to its children, the
+ * variable x (Op "x"), or a constant Value (Op "const")
+ */
+type GPNode struct {
+	Op       string
+	Arity    int
+	Children []*GPNode
+	Value    float64
+}
+
+/**
+ * GP DNA
+ * Represents an entity whose genome is an expression tree, for genetic
+ * programming such as symbolic regression. It keeps the settings it was
+ * created with, so that mutation can grow new subtrees alike.
+ */
+type GPDNA struct {
+	root    *GPNode
+	fitness float32
+
+	maxDepth  int
+	ops       []string
+	terminals []float64
+}
+
+/**
+ * GP Arity
+ * The number of children taken by each of the supported operators and terminals
+ */
+var gpArity = map[string]int{
+	"x":     0,
+	"const": 0,
+	"+":     2,
+	"-":     2,
+	"*":     2,
+	"/":     2,
+	"sin":   1,
+	"cos":   1,
+}
+
+/**
+ * Decision Tree DNA
+ * A complete binary decision tree decoded from DNA (see DecisionTreeDNACreate).
+ * Internal nodes are held breadth-first, each sending a sample left when its
+ * feature is at most its threshold; the leaves follow, each holding a class.
+ */
+type DecisionTreeDNA struct {
+	Features   []int
+	Thresholds []float32
+	Labels     []int
+}
+
+/**
+ * Decision Tree Alphabet
+ * The genes decision tree DNA is made from, and should be mutated with
+ */
+var DecisionTreeAlphabet = Alphabet{Runes: runeRange(0, 256)}
+
+/**
+ * Self-Adaptive DNA
+ * Represents an entity which carries its own mutation rate as part of its
+ * genome (stored as the natural log of the rate), so that the rate evolves
+ * alongside the solution
+ */
+type SelfAdaptiveDNA struct {
+	genes    []rune
+	logSigma float32
+	fitness  float32
+}
+
+/**
+ * Scored DNA
+ * Represents an entity which keeps the fitness of every assessment made over
+ * its lifetime, oldest first, as well as the latest
+ */
+type ScoredDNA struct {
+	DNA
+	FitnessHistory []float32
+}
+
+/**
+ * Schema
+ * A template of genes, in which the WildCard rune matches any gene and every
+ * other rune must match exactly, as in Holland's Schema Theorem
+ */
+type Schema struct {
+	Pattern  []rune
+	WildCard rune
+}
+
+/**
+ * Compact GA
+ * An evolutionary algorithm which holds a probability vector instead of an
+ * explicit population: each entry is the chance that one bit of the phrase is
+ * set, with eight bits per gene
+ */
+type CompactGA struct {
+	probVector []float32
+	target     string
+	iterations int
+	rng        *PRNG
+}
+
+/**
+ * Population
+ * Holds the entities of the population, the mating pool, and iteration information
+ */
+type Population struct {
+	entities     []DNA
+	matingPool   []DNA
+	generations  int
+	completed    bool
+	perfectScore float32
+	cfg          Config
+
+	// Cached best entity, valid until the entities change (CacheDirty)
+	bestIndex   int
+	bestFitness float32
+	CacheDirty  bool
+
+	// Recycled DNA for breeding children, created on first use
+	pool *DNAPool
+
+	// Held for writing while evolving a generation, and for reading by Snapshot
+	mu sync.RWMutex
+
+	MutationAdaptor MutationAdaptor
+
+	// The config's mutation rate before any adaptor changed it, restored by Reset
+	baseMutationRate float32
+
+	// Source of randomness for the population's operations, see Config.Seed
+	rng *PRNG
+
+	// Generations of hypermutation remaining, see HypermutationTrigger
+	hypermutationCountdown int
+
+	// Mutations made breeding the population, when the config audits them
+	Audit *MutationAudit
+
+	// Time spent in each phase of evolving the population
+	Timer PhaseTimer
+}
+
+/**
+ * Population Snapshot
+ * An immutable copy of a population's state, safe to read while the population
+ * continues to evolve
+ */
+type PopulationSnapshot struct {
+	Entities       []DNA
+	Generation     int
+	BestFitness    float32
+	AverageFitness float32
+}
+
+/**
+ * Generation Stats
+ * Summarises the fitness of a population's current generation. The median and
+ * quartiles, unlike the average, are not skewed by a few outlying entities.
+ */
+type GenerationStats struct {
+	Generation     int
+	BestFitness    float32
+	AverageFitness float32
+	MedianFitness  float32
+	LowerQuartile  float32
+	UpperQuartile  float32
+	BestPhrase     string
+
+	// Gene positions at which every entity shares the same rune, once equal to
+	// the gene length the population has fully converged
+	FixedAlleles int
+}
+
+/**
+ * Phase Timer
+ * The wall time spent in each phase of evolve (selection, generating the next
+ * generation, and calculating fitness), totalled over the generations timed
+ */
+type PhaseTimer struct {
+	Selection   time.Duration
+	Generate    time.Duration
+	Fitness     time.Duration
+	Generations int
+}
+
+/**
+ * Event Log
+ * Writes time-stamped events, with the stats of the generation they happened
+ * in, to a file as newline-delimited JSON for analysis after the run
+ */
+type EventLog struct {
+	w    *bufio.Writer
+	file *os.File
+	mu   sync.Mutex
+}
+
+/**
+ * DNA Pool
+ * Recycles DNA (and their gene slices) between generations, so that breeding a
+ * new generation does not allocate new genes for every child
+ */
+type DNAPool struct {
+	pool    sync.Pool
+	geneLen int
+}
+
+/**
+ * Migration Topology
+ * Returns the indexes of the islands connected to (and so able to exchange
+ * entities with) the given island, out of count islands
+ */
+type MigrationTopology func(island, count int) []int
+
+/**
+ * Island Model
+ * Evolves several populations (islands) side by side, periodically migrating the
+ * best entities between connected islands and crossing over entities across
+ * island boundaries
+ * With a dynamic migration interval, migration happens whenever the islands
+ * have diverged (see IslandModel.Divergence) beyond the divergence threshold,
+ * rather than every MigrationInterval generations.
+ */
+type IslandModel struct {
+	Islands                  []*Population
+	Topology                 MigrationTopology
+	MigrationInterval        int     // Generations between migrations
+	MigrationCount           int     // Entities migrated from each island
+	InterIslandCrossoverRate float32 // Probability of inter-island crossover each generation
+	DynamicMigrationInterval bool    // Migrate on divergence rather than at an interval
+	DivergenceThreshold      float64 // Divergence to migrate above, with a dynamic interval
+	PRNG                     *PRNG   // Source of randomness for inter-island crossover, GlobalPRNG if nil
+	generations              int
+	migrations               int
+}
+
+/**
+ * Coevolution Pair
+ * Two populations evolving interdependently, where the fitness of each host is
+ * decided by its interaction with the current best parasite, and vice versa
+ */
+type CoevolutionPair struct {
+	HostPopulation, ParasitePopulation *Population
+	InteractionFn                      func(host, parasite *DNA) (hostFitness, parasiteFitness float32)
+}
+
+/**
+ * Generational Archive
+ * Keeps a copy of the best entity of every generation it records, indexed from
+ * 0 in the order recorded. Once MaxSize (if above 0) generations are held, each
+ * new generation replaces the oldest. Record is intended to be used as a
+ * config's OnGenerationEnd hook.
+ */
+type GenerationalArchive struct {
+	BestPerGeneration []DNA
+	MaxSize           int
+
+	// Number of generations recorded, including those since replaced
+	recorded int
+}
+
+/**
+ * Population Recorder
+ * Records the outcome of a run, generation by generation. Record is intended
+ * to be used as a config's OnGenerationEnd hook.
+ */
+type PopulationRecorder struct {
+	BestFitness []float32
+	Generations int
+	Completed   bool
+
+	// The parent IDs of every entity recorded, by ID
+	Lineage map[int64][2]int64
+}
+
+/**
+ * Epoch
+ * A number of generations to evolve with the given config, see RunEpochs
+ */
+type Epoch struct {
+	Generations int
+	Config      Config
+}
+
+/**
+ * Run Comparison
+ * Statistics aggregated across independent runs (see CompareRuns)
+ */
+type RunComparison struct {
+	MeanGenerationsToSolution float64
+	StdDevGenerations         float64
+	SuccessRate               float32
+	MeanBestFitness           float32
+}
+
+/**
+ * Genome
+ * The behaviour shared by every kind of DNA, so that code handling entities
+ * generically need not be written once per gene type. CloneGenome is named so
+ * as not to clash with DNA's own Clone, which returns a DNA.
+ */
+type Genome interface {
+	Fitness() float32
+	SetFitness(fitness float32)
+	Len() int
+	CloneGenome() Genome
+}
+
+// Every kind of DNA is a Genome
+var (
+	_ Genome = (*DNA)(nil)
+	_ Genome = (*FloatDNA)(nil)
+	_ Genome = (*PackedBinaryDNA)(nil)
+	_ Genome = (*DiploidDNA)(nil)
+	_ Genome = (*GPDNA)(nil)
+)
+
+/**
+ * Mutation Adaptor
+ * Adapts a population's mutation rate, returning the rate to breed its next
+ * generation with
+ */
+type MutationAdaptor interface {
+	AdaptMutationRate(population *Population) float32
+}
+
+/**
+ * Resettable Mutation Adaptor
+ * A mutation adaptor holding state learned from the generations it has seen,
+ * which Reset forgets, starting again from the given mutation rate
+ */
+type ResettableMutationAdaptor interface {
+	MutationAdaptor
+	Reset(rate float32)
+}
+
+/**
+ * Hypermutation Trigger
+ * Spikes the population's mutation rate to HighRate for Duration generations
+ * once its best fitness has not improved for StagnationWindow generations,
+ * then returns it to the baseline rate it had before
+ */
+type HypermutationTrigger struct {
+	StagnationWindow int
+	HighRate         float32
+	Duration         int
+
+	// Best fitness of the generations since the last hypermutation
+	history  []float32
+	baseline float32
+}
+
+/**
+ * Parameter Grid
+ * The values of each parameter to search over (see GridSearch). An empty
+ * Selectors searches with the default selector alone.
+ */
+type ParameterGrid struct {
+	MaxPops        []int
+	MutRates       []float32
+	CrossoverRates []float32
+	Selectors      []Selector
+}
+
+/**
+ * Grid Result
+ * The parameter combination of a grid search, and the statistics of its runs
+ */
+type GridResult struct {
+	MaxPop          int
+	MutationRate    float32
+	CrossoverRate   float32
+	Selector        Selector
+	MeanGenerations float64
+	SuccessRate     float32
+	MeanFitness     float32
+}
+
+/**
+ * Minimum Adapted Mutation Rate
+ * The lowest rate the generation improvement adaptor decays to, as a rate of 0
+ * could never be boosted again
+ */
+const minAdaptedMutationRate = 0.0001
+
+/**
+ * Generation Improvement Adaptor
+ * Adapts the population's mutation rate based on how much the average fitness
+ * has improved over the last Window generations. Stagnation (improvement below
+ * Threshold) boosts the rate to encourage exploration, strong improvement
+ * decays it to encourage exploitation.
+ * Left at 0, Rate starts from the population's mutation rate. It never decays
+ * below minAdaptedMutationRate, from which it can still be boosted.
+ */
+type GenerationImprovementAdaptor struct {
+	History     []float32
+	Window      int
+	Threshold   float32
+	BoostFactor float32
+	DecayFactor float32
+	Rate        float32
+}
+
+/**
+ * Main Method
+ * Sets up the initial generation, then runs the evolution loop until an entity
+ * matches the target 100%
+ */
+func main() {
+	fmt.Println("Danw33's Golang-based Genetic Algorithm")
+	fmt.Println("Start time:", time.Now())
+	fmt.Println("Running with Max Population:", maxpop, "and Mutation Probability:", mutrate)
+	fmt.Println("Target Outcome: ", target)
+
+	// Seed the PRNG (only once!)
+	rand.Seed(time.Now().Unix())
+
+	// Sanity Check
+	//test()
+
+	var config = Config{
+		Target:          target,
+		MaxPop:          maxpop,
+		MutationRate:    mutrate,
+		GenerationMode:  generationMode,
+		Replacements:    replacements,
+		GenerationalGap: generationalGap,
+		CrossoverRate:   crossoverRate,
+		Alphabet:        PrintableASCII,
+		EliteCount:      eliteCount,
+		OnGenerationEnd: func(population *Population) {
+			fmt.Println(FormatProgressBar(PopulationGenerationStats(population), 40))
+		},
+	}
+
+	// Create the population (and Generation 0)
+	population, err := NewPopulation(config)
+	if err != nil {
+		fmt.Println("Unable to create population:", err)
+		os.Exit(1)
+	}
+
+	// Evolve
+	if err := RunWithContext(context.Background(), population); err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Solution Discovered at", time.Now(), "by Generation", population.generations, "with population", len(population.entities), "and mutation rate", mutrate, " Average fitness:", populationAverageFitness(population), "Final Phrase:", populationGetBest(population))
+
+	var average = population.Timer.AveragePerGeneration()
+	fmt.Println("Average time per generation - Selection:", average.Selection, "Generate:", average.Generate, "Fitness:", average.Fitness)
+}
+
+/**
+ * New Population
+ * Validates the given config, and creates a new population evolving with it,
+ * running the setup method to create Generation 0
+ * A config with a Seed evolves repeatably from a PRNG of its own, otherwise the
+ * global PRNG is used.
+ */
+func NewPopulation(cfg Config) (*Population, error) {
+	if err := validateConfig(&cfg); err != nil {
+		return nil, err
+	}
+
+	var population = &Population{entities: []DNA{}, matingPool: []DNA{}, perfectScore: 1.0, cfg: cfg, rng: GlobalPRNG, baseMutationRate: cfg.MutationRate}
+	if cfg.Seed != 0 {
+		population.rng = NewPRNG(cfg.Seed)
+	}
+	if cfg.AuditMutations {
+		population.Audit = &MutationAudit{}
+	}
+	setup(population)
+
+	return population, nil
+}
+
+/**
+ * New Population From Parents
+ * As NewPopulation, but generation 0 is bred from the given parents (such as
+ * the best of a related problem's population) rather than created at random.
+ * Each parent in turn, wrapping around, is crossed over with another picked at
+ * random, and the child mutated.
+ * There must be at least 2 parents, each with one gene per rune of the target.
+ */
+func NewPopulationFromParents(parents []DNA, cfg Config) (*Population, error) {
+	if len(parents) < 2 {
+		return nil, ErrInvalidConfig{"Parents", "must hold at least 2 parents"}
+	}
+
+	var geneLength = len([]rune(cfg.Target))
+	for i := range parents {
+		if len(parents[i].genes) != geneLength {
+			return nil, ErrGeneLengthMismatch{len(parents[i].genes), geneLength}
+		}
+	}
+
+	cfg.Initialization = parentsInitialization{parents}
+
+	return NewPopulation(cfg)
+}
+
+/**
+ * Parents Initialization: Initialize
+ * Breeds children of the parents until the population is full
+ */
+func (p parentsInitialization) Initialize(population *Population) {
+	var n = len(p.parents)
+	for i := 0; len(population.entities) < population.cfg.MaxPop; i++ {
+		var a = i % n
+		var b = (a + population.rng.Int(1, n)) % n
+
+		// The parents were checked to be the same length as the target
+		var child, _ = dnaCrossover(population.rng, &p.parents[a], &p.parents[b])
+		dnaMutate(population.rng, &child, population.cfg.MutationRate, population.cfg.Alphabet)
+		population.entities = append(population.entities, child)
+	}
+}
+
+/**
+ * Population: Reset
+ * Discards every entity and all progress, then sets the population up again
+ * with fresh random DNA drawn from a PRNG seeded with the given seed (or from
+ * GlobalPRNG when the seed is 0). The timer and any mutation audit start again
+ * empty, and the mutation rate returns to the config's own, before any mutation
+ * adaptor changed it. A ResettableMutationAdaptor is reset to that rate, any
+ * other adaptor is left as it is. The rest of the config is left unchanged.
+ */
+func (p *Population) Reset(seed int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entities = []DNA{}
+	p.matingPool = []DNA{}
+	p.generations = 0
+	p.completed = false
+	p.bestIndex = 0
+	p.bestFitness = 0
+	p.hypermutationCountdown = 0
+	p.Timer = PhaseTimer{}
+
+	if p.Audit != nil {
+		p.Audit = &MutationAudit{}
+	}
+
+	p.cfg.MutationRate = p.baseMutationRate
+	if adaptor, ok := p.MutationAdaptor.(ResettableMutationAdaptor); ok {
+		adaptor.Reset(p.baseMutationRate)
+	}
+
+	// Recycled DNA would share genes with the old entities
+	p.pool = nil
+
+	p.rng = GlobalPRNG
+	if seed != 0 {
+		p.rng = NewPRNG(seed)
+	}
+
+	setup(p)
+}
+
+/**
+ * Validate Config
+ * Checks the given config for values which would cause the evolution loop to
+ * misbehave, returning an ErrInvalidConfig describing the first problem found
+ */
+func validateConfig(c *Config) error {
+	switch {
+	case c.MaxPop < 2:
+		return ErrInvalidConfig{"MaxPop", "must be at least 2"}
+	case c.MutationRate < 0.0 || c.MutationRate > 1.0:
+		return ErrInvalidConfig{"MutationRate", "must be between 0.0 and 1.0"}
+	case c.CrossoverRate < 0.0 || c.CrossoverRate > 1.0:
+		return ErrInvalidConfig{"CrossoverRate", "must be between 0.0 and 1.0"}
+	case len(c.Target) == 0:
+		return ErrInvalidConfig{"Target", "must not be empty"}
+	case c.EliteCount < 0:
+		return ErrInvalidConfig{"EliteCount", "must not be negative"}
+	case c.EliteCount >= c.MaxPop:
+		return ErrInvalidConfig{"EliteCount", "must be less than MaxPop"}
+	case c.GenerationalGap < 0.0 || c.GenerationalGap > 1.0:
+		return ErrInvalidConfig{"GenerationalGap", "must be between 0.0 and 1.0"}
+	}
+
+	if s, ok := c.Selector.(*LinearRankSelector); ok && (s.SP < 1.0 || s.SP > 2.0) {
+		return ErrInvalidConfig{"Selector", "LinearRankSelector SP must be between 1.0 and 2.0"}
+	}
+
+	return nil
+}
+
+/**
+ * Initial Setup Method
+ * Generates Generation 0 of the population with all-new DNA (Random)
+ */
+func setup(population *Population) {
+	fmt.Println("Setting up at", time.Now())
+
+	fmt.Println("Populating Generation 0 Gene Pool with random DNA Geonomes")
+	var initialization = population.cfg.Initialization
+	if initialization == nil {
+		initialization = RandomInitialization{}
+	}
+	initialization.Initialize(population)
+	for i := range population.entities {
+		population.entities[i].ID = dnaNewID()
+	}
+
+	fmt.Println("Created Seed Entities:", len(population.entities))
+
+	fmt.Println("Calculating Generation 0 Fitness")
+	populationCalculateFitness(population, population.cfg.Target)
+	fmt.Println("Generation 0 Fitness has been calculated.")
+
+	fmt.Println("Setup Completed at", time.Now())
+}
+
+/**
+ * Random Initialization: Initialize
+ * Fills the population up to its maximum size with random DNA
+ */
+func (RandomInitialization) Initialize(population *Population) {
+	for len(population.entities) < population.cfg.MaxPop {
+		var newDna = DNA{}
+		dnaCreate(population.rng, &newDna, len([]rune(population.cfg.Target)), population.cfg.Alphabet)
+		population.entities = append(population.entities, newDna)
+	}
+}
+
+/**
+ * Partially Deterministic Initialization: Initialize
+ * Creates the hinted entities, then fills the rest of the population randomly
+ */
+func (p PartiallyDeterministic) Initialize(population *Population) {
+	if len(p.Hints) > 0 {
+		var hinted = int(p.HintFraction * float32(population.cfg.MaxPop))
+		for i := 0; i < hinted && len(population.entities) < population.cfg.MaxPop; i++ {
+			population.entities = append(population.entities, DNAFromString(p.Hints[i%len(p.Hints)]))
+		}
+	}
+
+	RandomInitialization{}.Initialize(population)
+}
+
+/**
+ * Run With Context
+ * Runs the evolution loop until the population flags itself as completed, the
+ * context is done, or a generation fails
+ */
+func RunWithContext(ctx context.Context, population *Population) (err error) {
+	if log := population.cfg.EventLog; log != nil {
+		defer func() {
+			if closeErr := log.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	return EvolveUntil(ctx, UntilSolved(), population)
+}
+
+/**
+ * Evolve Until
+ * Evolves the population until the condition is met, or the context is done
+ */
+func EvolveUntil(ctx context.Context, condition func(*Population) bool, population *Population) error {
+	for !condition(population) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := evolve(population); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Until Solved
+ * A condition met once the population has produced a perfect entity
+ */
+func UntilSolved() func(*Population) bool {
+	return func(population *Population) bool {
+		return population.completed
+	}
+}
+
+/**
+ * Until Fitness Above
+ * A condition met once the population's best fitness exceeds threshold
+ */
+func UntilFitnessAbove(threshold float32) func(*Population) bool {
+	return func(population *Population) bool {
+		if population.CacheDirty {
+			populationUpdateBest(population)
+		}
+		return population.bestFitness > threshold
+	}
+}
+
+/**
+ * Until Generation Reached
+ * A condition met once the population has evolved n generations
+ */
+func UntilGenerationReached(n int) func(*Population) bool {
+	return func(population *Population) bool {
+		return population.generations >= n
+	}
+}
+
+/**
+ * Multi Start
+ * Runs n independent populations with the same config in parallel, returning
+ * the one with the highest best fitness once all have finished. If the config
+ * has a Seed, each population is seeded with a seed derived from it. If the context
+ * is done first, the best population so far is returned with the context's
+ * error. Any hooks in the config are shared between the runs, so must be safe
+ * for concurrent use.
+ */
+func MultiStart(ctx context.Context, n int, cfg Config) (*Population, error) {
+	if n < 1 {
+		return nil, ErrInvalidConfig{"n", "must run at least 1 population"}
+	}
+
+	type result struct {
+		population *Population
+		err        error
+	}
+
+	var results = make(chan result, n)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		var run = cfg
+		if cfg.Seed != 0 {
+			run.Seed = cfg.Seed + int64(i)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var population, err = NewPopulation(run)
+			if err == nil {
+				err = RunWithContext(ctx, population)
+			}
+			results <- result{population, err}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	var best *Population
+	var firstErr error
+	for r := range results {
+		if r.err != nil && firstErr == nil {
+			firstErr = r.err
+		}
+		if r.population == nil {
+			continue
+		}
+
+		populationGetBest(r.population)
+		if best == nil || r.population.bestFitness > best.bestFitness {
+			best = r.population
+		}
+	}
+
+	return best, firstErr
+}
+
+/**
+ * Grid Search
+ * Runs every combination of the grid's parameters, once per seed (see
+ * Config.Seed) and in parallel, for up to maxGen generations each. Runs evolve towards the default
+ * target, scored with the given fitness function if it is not nil. The mean
+ * generations cover successful runs only, see CompareRuns.
+ * Combinations not yet run when the context is done are left out.
+ */
+func GridSearch(ctx context.Context, grid ParameterGrid, fitness FitnessFunc, seeds []int64, maxGen int) []GridResult {
+	var selectors = grid.Selectors
+	if len(selectors) == 0 {
+		selectors = []Selector{nil}
+	}
+
+	var results []GridResult
+	for _, maxPop := range grid.MaxPops {
+		for _, mutRate := range grid.MutRates {
+			for _, crossoverRate := range grid.CrossoverRates {
+				for _, selector := range selectors {
+					if ctx.Err() != nil {
+						return results
+					}
+
+					var cfg = Config{
+						Target:        target,
+						MaxPop:        maxPop,
+						MutationRate:  mutRate,
+						CrossoverRate: crossoverRate,
+						Selector:      selector,
+						Alphabet:      PrintableASCII,
+						FitnessFunc:   fitness,
+					}
+					var comparison = CompareRuns(gridSearchRuns(ctx, cfg, seeds, maxGen))
+
+					results = append(results, GridResult{
+						MaxPop:          maxPop,
+						MutationRate:    mutRate,
+						CrossoverRate:   crossoverRate,
+						Selector:        selector,
+						MeanGenerations: comparison.MeanGenerationsToSolution,
+						SuccessRate:     comparison.SuccessRate,
+						MeanFitness:     comparison.MeanBestFitness,
+					})
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+/**
+ * Grid Search: Runs
+ * Runs a population with the config for each seed in parallel, for up to maxGen
+ * generations, returning their recordings. Populations which fail to start
+ * record nothing.
+ */
+func gridSearchRuns(ctx context.Context, cfg Config, seeds []int64, maxGen int) []*PopulationRecorder {
+	var recorders = make([]*PopulationRecorder, len(seeds))
+	var wg sync.WaitGroup
+
+	for i := range recorders {
+		recorders[i] = &PopulationRecorder{}
+
+		var run = cfg
+		run.Seed = seeds[i]
+		run.OnGenerationEnd = recorders[i].Record
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if population, err := NewPopulation(run); err == nil {
+				RunN(ctx, maxGen, population)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return recorders
+}
+
+/**
+ * Run Epochs
+ * Evolves the population through each epoch in turn, for the epoch's number of
+ * generations with the epoch's config swapped in, such as a high mutation rate
+ * to explore early on and a low one to exploit later. Stops early if the
+ * population completes or the context is done.
+ * An epoch changing the Seed reseeds the population's PRNG, one changing
+ * AuditMutations starts or stops its mutation audit, and one changing the
+ * mutation rate resets the population's mutation adaptor (if resettable) to it.
+ * There must be at least one epoch, the first epoch's config must be the one
+ * the population is currently evolving with (see epochConfigMatches), and
+ * every epoch's config must be valid and evolve towards the population's
+ * target (whose length the genes are fixed at), otherwise an ErrInvalidConfig
+ * is returned before evolving.
+ */
+func RunEpochs(ctx context.Context, epochs []Epoch, population *Population) error {
+	if len(epochs) == 0 {
+		return ErrInvalidConfig{"Epochs", "must not be empty"}
+	}
+
+	population.mu.Lock()
+	var current = epochConfigMatches(&epochs[0].Config, population)
+	population.mu.Unlock()
+	if !current {
+		return ErrInvalidConfig{"Epochs", "must start with the population's current config"}
+	}
+
+	for i := range epochs {
+		if err := validateConfig(&epochs[i].Config); err != nil {
+			return err
+		}
+		if epochs[i].Config.Target != population.cfg.Target {
+			return ErrInvalidConfig{"Target", "must match the population's target in every epoch"}
+		}
+	}
+
+	for _, epoch := range epochs {
+		population.mu.Lock()
+		var previous = population.cfg
+		population.cfg = epoch.Config
+
+		if epoch.Config.MutationRate != population.baseMutationRate {
+			population.baseMutationRate = epoch.Config.MutationRate
+			if adaptor, ok := population.MutationAdaptor.(ResettableMutationAdaptor); ok {
+				adaptor.Reset(population.baseMutationRate)
+			}
+		}
+
+		if epoch.Config.Seed != previous.Seed {
+			population.rng = GlobalPRNG
+			if epoch.Config.Seed != 0 {
+				population.rng = NewPRNG(epoch.Config.Seed)
+			}
+		}
+
+		if !epoch.Config.AuditMutations {
+			population.Audit = nil
+		} else if population.Audit == nil {
+			population.Audit = &MutationAudit{}
+		}
+		population.mu.Unlock()
+
+		if err := RunN(ctx, epoch.Generations, population); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Epoch Config Matches
+ * Reports whether the config holds the settings the population is evolving
+ * with, of those an epoch would change: its target, sizes, rates, modes and
+ * seed. The mutation rate is compared before any adaptor rewrote it, and
+ * functions (which can't be compared) are not compared at all.
+ * The population's lock must be held.
+ */
+func epochConfigMatches(cfg *Config, population *Population) bool {
+	var current = &population.cfg
+
+	return cfg.Target == current.Target &&
+		cfg.MaxPop == current.MaxPop &&
+		cfg.MutationRate == population.baseMutationRate &&
+		cfg.CrossoverRate == current.CrossoverRate &&
+		cfg.GenerationMode == current.GenerationMode &&
+		cfg.Replacements == current.Replacements &&
+		cfg.GenerationalGap == current.GenerationalGap &&
+		cfg.EliteCount == current.EliteCount &&
+		cfg.TournamentSize == current.TournamentSize &&
+		cfg.Lambda == current.Lambda &&
+		cfg.PenaltyWeight == current.PenaltyWeight &&
+		cfg.AgingPenalty == current.AgingPenalty &&
+		cfg.ModeMutationRate == current.ModeMutationRate &&
+		cfg.Choosiness == current.Choosiness &&
+		cfg.Seed == current.Seed &&
+		cfg.AuditMutations == current.AuditMutations
+}
+
+/**
+ * Run N Generations
+ * Evolves the population exactly n times, stopping early if it completes or the
+ * context is done
+ */
+func RunN(ctx context.Context, n int, population *Population) error {
+	for i := 0; i < n && population.completed == false; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := evolve(population); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Evolution Loop Method
+ * Runs the Natural Selection, Generation, Fitness cycle
+ * To be called in a loop until the population flags itself as completed.
+ */
+func evolve(population *Population) error {
+	return evolveAssessing(population, func(population *Population) {
+		populationCalculateFitness(population, population.cfg.Target)
+	})
+}
+
+/**
+ * Evolution Loop Method: Assessing
+ * As evolve, but the new generation's fitness is calculated by the given
+ * function, which is called holding the population's lock
+ */
+func evolveAssessing(population *Population, assess func(population *Population)) error {
+	population.mu.Lock()
+	defer population.mu.Unlock()
+
+	// Generate mating pool
+	var start = time.Now()
+	populationSelect(population)
+	var selection = time.Since(start)
+
+	// Create next generation
+	start = time.Now()
+	if err := populationNextGeneration(population); err != nil {
+		return err
+	}
+	var generate = time.Since(start)
+
+	// Calculate fitness
+	start = time.Now()
+	assess(population)
+	population.Timer.record(selection, generate, time.Since(start))
+
+	// Restart a population that has converged too far
+	if population.cfg.RestartThreshold > 0 && PopulationUniqueCount(population) < population.cfg.RestartThreshold {
+		RestartPopulation(population)
+	}
+
+	// Adapt the mutation rate for the next generation
+	if population.MutationAdaptor != nil {
+		population.cfg.MutationRate = population.MutationAdaptor.AdaptMutationRate(population)
+	}
+
+	// Display Info
+	fmt.Println("Generation", population.generations, "with population", population.cfg.MaxPop, "and mutation rate", population.cfg.MutationRate, "completed with average fitness", populationAverageFitness(population), "Best Phrase:", populationGetBest(population))
+	if population.cfg.Verbose {
+		fmt.Println(PopulationAllPhrases(population, population.cfg.PhraseLimit))
+	}
+
+	if population.cfg.EventLog != nil {
+		if err := population.cfg.EventLog.Log("generation", PopulationGenerationStats(population)); err != nil {
+			return err
+		}
+	}
+
+	// Notify the hook, still holding the lock, so it must not call Snapshot
+	if population.cfg.OnGenerationEnd != nil {
+		population.cfg.OnGenerationEnd(population)
+	}
+
+	return nil
+}
+
+/**
+ * Generational Archive: Record
+ * Archives a copy of the population's current best entity
+ */
+func (a *GenerationalArchive) Record(population *Population) {
+	if population.CacheDirty {
+		populationUpdateBest(population)
+	}
+	var best = population.entities[population.bestIndex].Clone()
+
+	if a.MaxSize <= 0 || len(a.BestPerGeneration) < a.MaxSize {
+		a.BestPerGeneration = append(a.BestPerGeneration, best)
+	} else {
+		a.BestPerGeneration[a.slot(a.recorded)] = best
+	}
+	a.recorded++
+}
+
+/**
+ * Generational Archive: Best At Generation
+ * Returns the best entity of generation g, or empty DNA if g has not been
+ * recorded or has since been replaced
+ */
+func (a *GenerationalArchive) BestAtGeneration(g int) DNA {
+	if g < a.recorded-len(a.BestPerGeneration) || g >= a.recorded {
+		return DNA{}
+	}
+
+	return a.BestPerGeneration[a.slot(g)]
+}
+
+/**
+ * Generational Archive: Improvement History
+ * Returns the best fitness of each generation held, oldest first
+ */
+func (a *GenerationalArchive) ImprovementHistory() []float32 {
+	var history = make([]float32, 0, len(a.BestPerGeneration))
+	for g := a.recorded - len(a.BestPerGeneration); g < a.recorded; g++ {
+		history = append(history, a.BestPerGeneration[a.slot(g)].fitness)
+	}
+
+	return history
+}
+
+/**
+ * Generational Archive: First Generation Above
+ * Returns the first generation held whose best fitness is above threshold, or
+ * -1 if there is none
+ */
+func (a *GenerationalArchive) FirstGenerationAbove(threshold float32) int {
+	var first = a.recorded - len(a.BestPerGeneration)
+	for i, fitness := range a.ImprovementHistory() {
+		if fitness > threshold {
+			return first + i
+		}
+	}
+
+	return -1
+}
+
+/**
+ * Generational Archive: Slot
+ * Returns the position of generation g within BestPerGeneration
+ */
+func (a *GenerationalArchive) slot(g int) int {
+	if a.MaxSize <= 0 {
+		return g
+	}
+	return g % a.MaxSize
+}
+
+/**
+ * Population Recorder: Record
+ * Records the population's best fitness and progress for the generation just
+ * evolved
+ */
+func (r *PopulationRecorder) Record(population *Population) {
+	if population.CacheDirty {
+		populationUpdateBest(population)
+	}
+
+	r.BestFitness = append(r.BestFitness, population.bestFitness)
+	r.Generations = population.generations
+	r.Completed = population.completed
+
+	if r.Lineage == nil {
+		r.Lineage = make(map[int64][2]int64)
+	}
+	for i := range population.entities {
+		r.Lineage[population.entities[i].ID] = population.entities[i].ParentIDs
+	}
+}
+
+/**
+ * Compare Runs
+ * Aggregates the recorded runs. A run succeeds if it completed within the
+ * generations it was given; the generations statistics cover successful runs
+ * only, and the best fitness is the highest each run recorded.
+ */
+func CompareRuns(runs []*PopulationRecorder) RunComparison {
+	var comparison RunComparison
+	if len(runs) == 0 {
+		return comparison
+	}
+
+	var successes []float64
+	var bestTotal float32
+	for _, run := range runs {
+		if run.Completed {
+			successes = append(successes, float64(run.Generations))
+		}
+
+		var best float32
+		for _, fitness := range run.BestFitness {
+			if fitness > best {
+				best = fitness
+			}
+		}
+		bestTotal += best
+	}
+
+	comparison.SuccessRate = float32(len(successes)) / float32(len(runs))
+	comparison.MeanBestFitness = bestTotal / float32(len(runs))
+
+	if len(successes) > 0 {
+		for _, generations := range successes {
+			comparison.MeanGenerationsToSolution += generations
+		}
+		comparison.MeanGenerationsToSolution /= float64(len(successes))
+
+		var variance float64
+		for _, generations := range successes {
+			variance += math.Pow(generations-comparison.MeanGenerationsToSolution, 2)
+		}
+		comparison.StdDevGenerations = math.Sqrt(variance / float64(len(successes)))
+	}
+
+	return comparison
+}
+
+/**
+ * Population: Snapshot
+ * Returns a deep copy of the population's current state, taken under a read
+ * lock so that it is safe to call while another goroutine is evolving it
+ */
+func (p *Population) Snapshot() PopulationSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var snapshot = PopulationSnapshot{
+		Entities:   make([]DNA, len(p.entities)),
+		Generation: p.generations,
+	}
+
+	var total float32
+	for i := range p.entities {
+		snapshot.Entities[i] = p.entities[i].Clone()
+		total += p.entities[i].fitness
+		if p.entities[i].fitness > snapshot.BestFitness {
+			snapshot.BestFitness = p.entities[i].fitness
+		}
+	}
+	if len(p.entities) > 0 {
+		snapshot.AverageFitness = total / float32(len(p.entities))
+	}
+
+	return snapshot
+}
+
+/**
+ * Population: Sort Entities
+ * Sorts the entities by descending fitness, so that entities[0] is the best.
+ * The sort is stable: entities of equal fitness keep their relative order.
+ * Takes the population's lock, so must not be called from OnGenerationEnd.
+ */
+func (p *Population) SortEntities() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sort.SliceStable(p.entities, func(i, j int) bool {
+		return p.entities[i].fitness > p.entities[j].fitness
+	})
+	p.CacheDirty = true
+}
+
+/**
+ * Population: Is Sorted
+ * Reports whether the entities are sorted by descending fitness, without
+ * sorting them
+ */
+func (p *Population) IsSorted() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return sort.SliceIsSorted(p.entities, func(i, j int) bool {
+		return p.entities[i].fitness > p.entities[j].fitness
+	})
+}
+
+/**
+ * Population: Select
+ * Fills the mating pool using the configured selector, or natural selection
+ */
+func populationSelect(population *Population) {
+	if population.cfg.Selector != nil {
+		population.cfg.Selector.Select(population)
+	} else {
+		populationNaturalSelection(population)
+	}
+}
+
+/**
+ * Population: Next Generation
+ * Breeds the next generation from the mating pool using the configured
+ * generation mode
+ */
+func populationNextGeneration(population *Population) error {
+	switch population.cfg.GenerationMode {
+	case SteadyState:
+		return SteadyStateGenerate(population, population.cfg.Replacements)
+	case TournamentReplacementMode:
+		// Every entity ages a generation, the children replacing some start afresh
+		for i := range population.entities {
+			population.entities[i].Age++
+		}
+
+		for i := 0; i < len(population.entities); i++ {
+			if err := TournamentReplacement(population, population.cfg.TournamentSize); err != nil {
+				return err
+			}
+		}
+		population.generations++
+		return nil
+	case MuPlusLambdaMode:
+		var lambda = population.cfg.Lambda
+		if lambda <= 0 {
+			lambda = len(population.entities)
+		}
+		return MuPlusLambdaGenerate(population, lambda)
+	case MuCommaLambdaMode:
+		var lambda = population.cfg.Lambda
+		if lambda <= 0 {
+			lambda = 2 * len(population.entities)
+		}
+		return MuCommaLambdaGenerate(population, len(population.entities), lambda)
+	default:
+		return populationGenerate(population)
+	}
+}
+
+func test() {
+
+	fmt.Println("Running basic test. Will Generate two parents, crossover and mutuate.")
+
+	var rng = NewPRNG(42)
+
+	var dnaA = DNA{}
+	dnaCreate(rng, &dnaA, len(target), PrintableASCII)
+	dnaAssessFitness(&dnaA, target)
+	fmt.Println("Parent 1 (DNA A) Fitness:", dnaA.fitness, "Phrase:", dnaExtractPhrase(&dnaA))
+
+	var dnaB = DNA{}
+	dnaCreate(rng, &dnaB, len(target), PrintableASCII)
+	dnaAssessFitness(&dnaB, target)
+	fmt.Println("Parent 2 (DNA B) Fitness:", dnaB.fitness, "Phrase:", dnaExtractPhrase(&dnaB))
+
+	var dnaC, _ = dnaCrossover(rng, &dnaA, &dnaB)
+	dnaMutate(rng, &dnaC, mutrate, PrintableASCII)
+	dnaAssessFitness(&dnaC, target)
+	fmt.Println("Child    (DNA C) Fitness:", dnaC.fitness, "Phrase:", dnaExtractPhrase(&dnaC))
+
+	fmt.Println("Manipulating Child geonome (DNA C => DNA D) to test fitness assessment")
+
+	// Mutate the genes at positions 0 to 2 to match the target
+	var dnaD = DNAFromRunes(append([]rune(target[:3]), dnaC.genes[3:]...))
+
+	dnaAssessFitness(&dnaD, target)
+	fmt.Println("Child    (DNA D) Fitness:", dnaD.fitness*100, "Phrase:", dnaExtractPhrase(&dnaD))
+
+	testDiploidDominance()
+	testSteadyState()
+	testGenerationalGap()
+	testDynamicTarget()
+	testGenerationImprovementAdaptor()
+	testCrossoverRate()
+	testPerGeneMutation()
+	testSelfAdaptiveMutation()
+	testRepairPermutation()
+	testInfeasibleCount()
+	testPenaltyWeight()
+	testFeasibilityRuleSelection()
+	testDNA4Alphabet()
+	testAlphabetOperators()
+	testNucleotideFitness()
+	testGCContent()
+	testCompressionFitness()
+	testNeuralNetFitness()
+	testRastriginConvergence()
+	testRosenbrockConvergence()
+	testSphereFitness2D()
+	testErrorTypes()
+	testInvalidConfigError()
+	testValidateConfig()
+	testClone()
+	testPackedBinaryCrossover()
+	testDynamicPopulationSizing()
+	testIslandCrossover()
+	testSexualSelectionDistance()
+	testCoevolutionPair()
+	testNaturalSelection()
+	testZeroFitness()
+	testEmptyMatingPool()
+	testGeneLengthMismatch()
+	testConcurrentSnapshot()
+	testRunN()
+	testTopK()
+	testHammingDistance()
+	testAllPhrases()
+	testEvolveUntil()
+	testPickParents()
+	testRestart()
+	testMultiTarget()
+	testMigrateInject()
+	testGenerationalArchive()
+	testCompareRuns()
+	testTournamentWithoutReplacement()
+	testLinearRank()
+	testGP()
+	testDecisionTree()
+	testFeatureSelection()
+	testAging()
+	testMultiStart()
+	testLaMarckian()
+	testFitnessCache()
+	testNoveltyArchive()
+	testBoltzmannSharing()
+	testHypermutation()
+	testReproductionMode()
+	testGreedyCrossover()
+	testDynamicMigration()
+	testGridSearch()
+	testTournamentReplacement()
+	testPolynomialMutation()
+	testCompactGA()
+	testMuPlusLambda()
+	testMuCommaLambda()
+	testPopulationReset()
+	testMedianFitness()
+	testDNADiff()
+	testExtractPhraseRange()
+	testScoredDNA()
+	testPartiallyDeterministicInitialization()
+	testWeightedSumFitness()
+	testFormatProgressBar()
+	testEventLog()
+	testPopulationDot()
+	testAsyncEvaluator()
+	testFilterPopulation()
+	testMapPopulation()
+	testConsensusSequence()
+	testZipPopulations()
+	testConstrainedDNACreate()
+	testCoevolutionaryFitness()
+	testDetectFixedAlleles()
+	testAlleleFrequencies()
+	testSchema()
+	testMutationAudit()
+	testPhaseTimer()
+	testRunEpochs()
+	testSortEntities()
+	testGenome()
+	testNewPopulationFromParents()
+	testWeightedAverageFitness()
+
+	fmt.Println("Testing concluded, see console for data to analyse.")
+}
+
+/**
+ * Diploid Dominance Check
+ * Checks that wherever a dominant allele is paired with the allele it dominates,
+ * on either strand, the dominant allele is expressed, and that diploid DNA of
+ * no genes crosses over into a child of no genes
+ */
+func testDiploidDominance() {
+	fmt.Println("Checking diploid DNA expresses the dominant allele.")
+
+	var rng = NewPRNG(42)
+	var dominant, recessive = 'A', 'b'
+	var diploid = DiploidDNA{dominanceTable: map[rune]rune{recessive: dominant}}
+	for i := 0; i < 100; i++ {
+		if rng.Int(0, 2) == 1 {
+			diploid.genesA = append(diploid.genesA, dominant)
+			diploid.genesB = append(diploid.genesB, recessive)
+		} else {
+			diploid.genesA = append(diploid.genesA, recessive)
+			diploid.genesB = append(diploid.genesB, dominant)
+		}
+	}
+
+	var recessiveExpressed int
+	for _, allele := range dnaExpressDiploid(&diploid) {
+		if allele != dominant {
+			recessiveExpressed++
+		}
+	}
+
+	if recessiveExpressed == 0 {
+		fmt.Println("PASS: the dominant allele was expressed at all 100 loci")
+	} else {
+		fmt.Println("FAIL: the recessive allele was expressed at", recessiveExpressed, "of 100 loci")
+	}
+
+	var empty = DiploidDNA{dominanceTable: diploid.dominanceTable}
+	var ok = testRecover(func() bool {
+		var child = dnaCrossoverDiploid(rng, &empty, &empty)
+		return len(child.genesA) == 0 && len(child.genesB) == 0
+	})
+
+	if ok {
+		fmt.Println("PASS: diploid DNA of no genes crossed over into a child of no genes")
+	} else {
+		fmt.Println("FAIL: diploid DNA of no genes did not cross over into a child of no genes")
+	}
+}
+
+/**
+ * Steady-State Check
+ * Checks that a steady-state generation replaces exactly the given number of
+ * worst entities, leaving the population's size unchanged, and that the copies
+ * of the child it breeds keep its reproduction mode
+ */
+func testSteadyState() {
+	fmt.Println("Checking steady-state generations replace the worst entities.")
+
+	var population = Population{cfg: Config{Target: target, MaxPop: 100, MutationRate: mutrate, GenerationMode: SteadyState, Replacements: 10}, perfectScore: 1.0}
+	setup(&population)
+	for i := range population.entities {
+		population.entities[i].ReproductionMode = ClonalMode
+	}
+	populationNaturalSelection(&population)
+
+	var worst = populationWorstOrder(&population)
+	// Replaced entities are given new genes, so are told apart by their gene slice
+	var genes = make([]*rune, len(population.entities))
+	for i := range population.entities {
+		genes[i] = &population.entities[i].genes[0]
+	}
+
+	SteadyStateGenerate(&population, 10)
+
+	var isWorst = make(map[int]bool)
+	for _, i := range worst[:10] {
+		isWorst[i] = true
+	}
+
+	var replaced, wrong int
+	for i := range population.entities {
+		if &population.entities[i].genes[0] != genes[i] {
+			replaced++
+			if !isWorst[i] {
+				wrong++
+			}
+		}
+	}
+
+	if len(population.entities) == 100 && replaced == 10 && wrong == 0 {
+		fmt.Println("PASS: the 10 worst of 100 entities were replaced")
+	} else {
+		fmt.Println("FAIL:", replaced, "entities were replaced,", wrong, "of them not among the worst 10, leaving", len(population.entities))
+	}
+
+	var crossover int
+	for i := range population.entities {
+		if population.entities[i].ReproductionMode != ClonalMode {
+			crossover++
+		}
+	}
+
+	if crossover == 0 {
+		fmt.Println("PASS: every entity is still clonal")
+	} else {
+		fmt.Println("FAIL:", crossover, "entities lost their clonal reproduction mode")
+	}
+}
+
+/**
+ * Generational Gap Check
+ * Checks that populations replacing 10%, 50% and 100% of their entities each
+ * generation all reach a fitness of 0.95, and that a gap smaller than one
+ * entity still replaces one
+ */
+func testGenerationalGap() {
+	fmt.Println("Checking populations evolve with generational gaps of 0.1, 0.5 and 1.0.")
+
+	var bestFitness = func(population *Population) float32 {
+		var best float32
+		for i := range population.entities {
+			if population.entities[i].fitness > best {
+				best = population.entities[i].fitness
+			}
+		}
+		return best
+	}
+
+	for _, gap := range []float32{0.1, 0.5, 1.0} {
+		var population = Population{cfg: Config{Target: "Hello!", MaxPop: 100, MutationRate: mutrate, GenerationalGap: gap}, perfectScore: 1.0}
+		setup(&population)
+
+		for population.generations < 10000 && bestFitness(&population) <= 0.95 {
+			evolve(&population)
+		}
+
+		if bestFitness(&population) > 0.95 {
+			fmt.Println("PASS: a gap of", gap, "reached fitness 0.95 by generation", population.generations)
+		} else {
+			fmt.Println("FAIL: a gap of", gap, "did not reach fitness 0.95 in", population.generations, "generations")
+		}
+	}
+
+	// 10% of 5 entities rounds down to none, but one must still be replaced
+	var small = Population{cfg: Config{Target: target, MaxPop: 5, MutationRate: mutrate, GenerationalGap: 0.1}, perfectScore: 1.0}
+	setup(&small)
+
+	// Breed from the entities themselves, as 5 random entities may all score 0
+	small.matingPool = small.entities
+	var genes = make([]*rune, len(small.entities))
+	for i := range small.entities {
+		genes[i] = &small.entities[i].genes[0]
+	}
+	populationGenerate(&small)
+
+	var replaced int
+	for i := range small.entities {
+		if &small.entities[i].genes[0] != genes[i] {
+			replaced++
+		}
+	}
+
+	if replaced == 1 {
+		fmt.Println("PASS: a gap of 0.1 replaced 1 of 5 entities")
+	} else {
+		fmt.Println("FAIL: a gap of 0.1 replaced", replaced, "of 5 entities")
+	}
+
+	if _, err := NewPopulation(Config{Target: target, MaxPop: 10, MutationRate: mutrate, CrossoverRate: 1.0, GenerationalGap: 1.5}); err != nil {
+		fmt.Println("PASS: a generational gap of 1.5 is rejected:", err)
+	} else {
+		fmt.Println("FAIL: a generational gap of 1.5 was accepted")
+	}
+}
+
+/**
+ * Dynamic Target Check
+ * Checks that when a cyclic target switches at generation 50 the average
+ * fitness drops, then recovers as the population tracks the new target
+ */
+func testDynamicTarget() {
+	fmt.Println("Checking a population tracks a target which switches at generation 50.")
+
+	var population = Population{cfg: Config{Target: "abcdefghij", MaxPop: 200, MutationRate: mutrate,
+		DynamicTargetFn: CyclicTarget([]string{"abcdefghij", "JIHGFEDCBA"}, 50)}, perfectScore: 1.0}
+	setup(&population)
+
+	var averages = make(map[int]float32)
+	for population.generations < 99 {
+		evolve(&population)
+		averages[population.generations] = populationAverageFitness(&population)
+	}
+
+	if averages[50] < averages[49] && averages[99] > averages[50] {
+		fmt.Println("PASS: average fitness fell from", averages[49], "to", averages[50], "at the switch and recovered to", averages[99])
+	} else {
+		fmt.Println("FAIL: average fitness went from", averages[49], "to", averages[50], "at the switch, then", averages[99])
+	}
+}
+
+/**
+ * Generation Improvement Adaptor Check
+ * Checks that the adaptor boosts a low mutation rate while the average fitness
+ * stagnates, then decays it again once the average improves
+ */
+func testGenerationImprovementAdaptor() {
+	fmt.Println("Checking the mutation rate adapts to stagnation and improvement.")
+
+	var adaptor = GenerationImprovementAdaptor{Window: 3, Threshold: 0.05, BoostFactor: 2.0, DecayFactor: 0.5, Rate: 0.01}
+
+	var peak float32
+	for i := 0; i < 6; i++ {
+		peak = adaptor.Adapt(0.5)
+	}
+
+	var final float32
+	for _, average := range []float32{0.6, 0.7, 0.8, 0.9} {
+		final = adaptor.Adapt(average)
+	}
+
+	if peak > 0.01 && final < peak {
+		fmt.Println("PASS: stagnation boosted the rate from 0.01 to", peak, "and improvement decayed it to", final)
+	} else {
+		fmt.Println("FAIL: stagnation took the rate from 0.01 to", peak, "and improvement to", final)
+	}
+
+	// Without a rate, the adaptor starts from the population's, and long
+	// improvement cannot decay it to 0
+	var population = Population{cfg: Config{Target: target, MaxPop: 10, MutationRate: 0.02}}
+	var unset = GenerationImprovementAdaptor{Window: 1, Threshold: 0.05, BoostFactor: 2.0, DecayFactor: 0.5}
+	var start = unset.AdaptMutationRate(&population)
+
+	var decayed float32
+	for i := 0; i < 100; i++ {
+		decayed = unset.Adapt(float32(i))
+	}
+	var boosted = unset.Adapt(99)
+
+	if start == 0.02 && decayed > 0 && boosted > decayed {
+		fmt.Println("PASS: an unset rate started from the population's", start, "and decayed to", decayed, "then boosted to", boosted)
+	} else {
+		fmt.Println("FAIL: an unset rate started at", start, "and decayed to", decayed, "then boosted to", boosted)
+	}
+}
+
+/**
+ * Crossover Rate Check
+ * Checks that without mutation, a crossover rate of 0.0 breeds only copies of
+ * the mating pool, while a crossover rate of 1.0 breeds children mixing the
+ * genes of two parents
+ */
+func testCrossoverRate() {
+	fmt.Println("Checking crossover rates of 0.0 and 1.0.")
+
+	for _, rate := range []float32{0.0, 1.0} {
+		var population = Population{cfg: Config{Target: target, MaxPop: 100, MutationRate: 0.0, CrossoverRate: rate}, perfectScore: 1.0}
+		setup(&population)
+
+		populationNaturalSelection(&population)
+		var pool = make(map[string]bool)
+		for i := range population.matingPool {
+			pool[dnaExtractPhrase(&population.matingPool[i])] = true
+		}
+
+		populationGenerate(&population)
+
+		var copies int
+		for i := range population.entities {
+			if pool[dnaExtractPhrase(&population.entities[i])] {
+				copies++
+			}
+		}
+
+		switch {
+		case rate == 0.0 && copies == len(population.entities):
+			fmt.Println("PASS: with a crossover rate of 0.0 every child copies a parent")
+		case rate == 1.0 && copies < len(population.entities)/4:
+			fmt.Println("PASS: with a crossover rate of 1.0 only", copies, "children copy a parent")
+		default:
+			fmt.Println("FAIL: with a crossover rate of", rate, copies, "of", len(population.entities), "children copy a parent")
+		}
+	}
+}
+
+/**
+ * Per-Gene Mutation Check
+ * Checks that genes with a mutation rate of 0.0 are never mutated and genes with
+ * a rate of 1.0 always are, and that a rate per gene is required
+ */
+func testPerGeneMutation() {
+	fmt.Println("Checking per-gene mutation rates of 0.0 and 1.0.")
+
+	var rng = NewPRNG(42)
+	var rates = make([]float32, 20)
+	for i := range rates {
+		rates[i] = float32(i % 2)
+	}
+
+	// Mutation only draws printable runes, so a mutated gene can never stay 0
+	var wrong int
+	for trial := 0; trial < 100; trial++ {
+		var entity = DNA{genes: make([]rune, len(rates))}
+		if err := dnaMutatePerGene(rng, &entity, rates, PrintableASCII); err != nil {
+			fmt.Println("FAIL: could not mutate entity:", err)
+			return
+		}
+		for i, gene := range entity.genes {
+			if (rates[i] == 0.0) != (gene == 0) {
+				wrong++
+			}
+		}
+	}
+
+	if wrong == 0 {
+		fmt.Println("PASS: genes at rate 0.0 were never mutated, and at rate 1.0 always were")
+	} else {
+		fmt.Println("FAIL:", wrong, "genes were mutated against their rate")
+	}
+
+	var entity = DNA{genes: make([]rune, 5)}
+	if err := dnaMutatePerGene(rng, &entity, rates, PrintableASCII); err != nil {
+		fmt.Println("PASS: mutating 5 genes with 20 rates is rejected:", err)
+	} else {
+		fmt.Println("FAIL: 5 genes were mutated with 20 rates")
+	}
+}
+
+/**
+ * Self-Adaptive Mutation Check
+ * Checks that entities starting with a disruptive mutation rate of 0.5 evolve
+ * their own rates down to a useful range for the target, around one mutation
+ * per phrase
+ */
+func testSelfAdaptiveMutation() {
+	fmt.Println("Checking self-adaptive mutation rates converge.")
+
+	var rng = NewPRNG(42)
+	var entities = make([]SelfAdaptiveDNA, 100)
+	for i := range entities {
+		dnaCreateSelfAdaptive(rng, &entities[i], len(target), 0.5, PrintableASCII)
+		dnaAssessSelfAdaptiveFitness(&entities[i], target)
+	}
+
+	// Each generation, the fitter half breed the next
+	for generation := 0; generation < 300; generation++ {
+		sort.SliceStable(entities, func(i, j int) bool { return entities[i].fitness > entities[j].fitness })
+
+		var next = make([]SelfAdaptiveDNA, len(entities))
+		for i := range next {
+			var child, _ = dnaCrossoverSelfAdaptive(rng, &entities[rng.Int(0, len(entities)/2)], &entities[rng.Int(0, len(entities)/2)])
+			dnaMutateSelfAdaptive(rng, &child, PrintableASCII)
+			dnaAssessSelfAdaptiveFitness(&child, target)
+			next[i] = child
+		}
+		entities = next
+	}
+
+	var logSigma float64
+	for i := range entities {
+		logSigma += float64(entities[i].logSigma)
+	}
+	var rate = math.Exp(logSigma / float64(len(entities)))
+
+	if rate > 0.001 && rate < 0.15 {
+		fmt.Println("PASS: the mutation rate evolved from 0.5 to", rate)
+	} else {
+		fmt.Println("FAIL: the mutation rate evolved from 0.5 to", rate)
+	}
+}
+
+/**
+ * Permutation Repair Check
+ * Checks that children of valid permutations, crossed over at a single point or
+ * by voting, are valid permutations of the same alphabet once repaired
+ */
+func testRepairPermutation() {
+	fmt.Println("Checking repaired permutations are valid.")
+
+	var rng = NewPRNG(42)
+	var alphabet = []rune("abcdefghij")
+	var permutation = func() DNA {
+		var genes = make([]rune, len(alphabet))
+		for i, j := range rng.Perm(len(alphabet)) {
+			genes[i] = alphabet[j]
+		}
+		return DNA{genes: genes}
+	}
+	var valid = func(dna *DNA) bool {
+		var sorted = append([]rune(nil), dna.genes...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		return string(sorted) == string(alphabet)
+	}
+
+	var invalid, repairedInvalid int
+	for trial := 0; trial < 500; trial++ {
+		var a, b = permutation(), permutation()
+		var singlePoint, err = dnaCrossover(rng, &a, &b)
+		if err != nil {
+			fmt.Println("FAIL: could not cross over permutations:", err)
+			return
+		}
+
+		for _, child := range []DNA{singlePoint, VotingCrossover(rng, a, b)} {
+			if !valid(&child) {
+				invalid++
+			}
+			if !valid(dnaRepairPermutation(&child, alphabet)) {
+				repairedInvalid++
+			}
+		}
+	}
+
+	if invalid > 0 && repairedInvalid == 0 {
+		fmt.Println("PASS: all", invalid, "invalid children of 1000 were repaired")
+	} else {
+		fmt.Println("FAIL:", repairedInvalid, "children were still invalid after repair, of", invalid, "invalid before")
+	}
+}
+
+/**
+ * Knapsack Check Problem
+ * A 20 item knapsack for the constraint checks, with one gene per item set to
+ * '1' when it is packed. Returns whether a packing fits in a capacity of the
+ * given share of the total weight, and by how much weight it overflows.
+ */
+func knapsackProblem(share float32) (fits func(*DNA) bool, overflow func(*DNA) float32) {
+	var weights = make([]float32, 20)
+	var capacity float32
+	for i := range weights {
+		weights[i] = float32(i%5 + 1)
+		capacity += weights[i] * share
+	}
+
+	overflow = func(dna *DNA) float32 {
+		var weight float32
+		for i, gene := range dna.genes {
+			if gene == '1' {
+				weight += weights[i]
+			}
+		}
+		return float32(math.Max(0, float64(weight-capacity)))
+	}
+	fits = func(dna *DNA) bool {
+		return overflow(dna) == 0
+	}
+
+	return fits, overflow
+}
+
+/**
+ * Knapsack Check Population
+ * Returns a population of 100 random packings of the knapsack, scored against a
+ * target packing every item so that each packed item is worth the same. Any
+ * gene other than '1', as mutation may draw, leaves its item unpacked.
+ */
+func knapsackPopulation(cfg Config) *Population {
+	cfg.Target, cfg.MaxPop, cfg.MutationRate, cfg.CrossoverRate = "11111111111111111111", 100, mutrate, 1.0
+
+	var population = Population{cfg: cfg, perfectScore: 1.0}
+	for i := 0; i < cfg.MaxPop; i++ {
+		var genes = make([]rune, len(cfg.Target))
+		for j := range genes {
+			genes[j] = rune('0' + random(0, 2))
+		}
+		population.entities = append(population.entities, DNA{genes: genes})
+	}
+	populationCalculateFitness(&population, cfg.Target)
+
+	return &population
+}
+
+/**
+ * Infeasible Count Check
+ * Checks that on the knapsack, where half of the random packings overflow, the
+ * number of infeasible entities falls as the population evolves
+ */
+func testInfeasibleCount() {
+	fmt.Println("Checking the infeasible count falls over generations.")
+
+	var fits, _ = knapsackProblem(0.5)
+	var population = knapsackPopulation(Config{ConstraintFn: fits})
+
+	var before = InfeasibleCount(population)
+	for i := 0; i < 20; i++ {
+		evolve(population)
+	}
+
+	if after := InfeasibleCount(population); after < before/2 {
+		fmt.Println("PASS: infeasible entities fell from", before, "to", after, "in 20 generations")
+	} else {
+		fmt.Println("FAIL: infeasible entities went from", before, "to", after, "in 20 generations")
+	}
+}
+
+/**
+ * Penalty Weight Check
+ * Checks that on the knapsack, a penalty weight of 0 scores every packing as no
+ * constraint at all does, and a huge penalty weight as the death penalty does
+ */
+func testPenaltyWeight() {
+	fmt.Println("Checking penalty weights of 0 and 1e9.")
+
+	var fits, overflow = knapsackProblem(0.5)
+	var population = knapsackPopulation(Config{})
+	var scores = func(cfg Config) string {
+		cfg.Target = population.cfg.Target
+		population.cfg = cfg
+		populationCalculateFitness(population, cfg.Target)
+
+		var fitness = make([]float32, len(population.entities))
+		for i := range population.entities {
+			fitness[i] = population.entities[i].fitness
+		}
+		return fmt.Sprint(fitness)
+	}
+
+	if scores(Config{PenaltyFn: overflow, PenaltyWeight: 0}) == scores(Config{}) {
+		fmt.Println("PASS: a penalty weight of 0 scored every packing as an unconstrained population")
+	} else {
+		fmt.Println("FAIL: a penalty weight of 0 scored packings differently to an unconstrained population")
+	}
+
+	if scores(Config{PenaltyFn: overflow, PenaltyWeight: 1e9}) == scores(Config{ConstraintFn: fits}) {
+		fmt.Println("PASS: a penalty weight of 1e9 scored every packing as the death penalty")
+	} else {
+		fmt.Println("FAIL: a penalty weight of 1e9 scored packings differently to the death penalty")
+	}
+}
+
+/**
+ * Feasibility Rule Selection Check
+ * Checks that on a knapsack so tight that no random packing fits, leaving the
+ * death penalty nothing to select, selecting by the feasibility rules still
+ * makes every entity feasible
+ */
+func testFeasibilityRuleSelection() {
+	fmt.Println("Checking feasibility rule selection reaches full feasibility.")
+
+	var fits, overflow = knapsackProblem(0.1)
+	var population = knapsackPopulation(Config{ConstraintFn: fits, Selector: FeasibilityRuleSelection(fits, overflow)})
+	var before = InfeasibleCount(population)
+
+	for population.generations < 200 && InfeasibleCount(population) > 0 {
+		evolve(population)
+	}
+
+	if InfeasibleCount(population) == 0 {
+		fmt.Println("PASS: from", before, "infeasible entities, every entity was feasible by generation", population.generations)
+	} else {
+		fmt.Println("FAIL: from", before, "infeasible entities,", InfeasibleCount(population), "were still infeasible after", population.generations, "generations")
+	}
+}
+
+/**
+ * DNA4 Alphabet Check
+ * Checks that a population created and evolved with the DNA4 alphabet only ever
+ * holds the bases A, T, G and C
+ */
+func testDNA4Alphabet() {
+	fmt.Println("Checking a DNA4 population only holds the bases A, T, G and C.")
+
+	var population = Population{cfg: Config{Target: "GATTACAGATTACA", MaxPop: 100, MutationRate: 0.1, CrossoverRate: 1.0, Alphabet: DNA4}, perfectScore: 1.0}
+	setup(&population)
+
+	var other int
+	for generation := 0; generation <= 20; generation++ {
+		for i := range population.entities {
+			for _, gene := range population.entities[i].genes {
+				switch gene {
+				case 'A', 'T', 'G', 'C':
+				default:
+					other++
+				}
+			}
+		}
+		evolve(&population)
+	}
+
+	if other == 0 {
+		fmt.Println("PASS: no other gene appeared in 20 generations")
+	} else {
+		fmt.Println("FAIL:", other, "genes outside the bases appeared in 20 generations")
+	}
+}
+
+/**
+ * Alphabet Operators Check
+ * Checks that per-gene, variable rate, diploid and self-adaptive creation and
+ * mutation all pick their genes from the given alphabet
+ */
+func testAlphabetOperators() {
+	fmt.Println("Checking every creation and mutation operator picks from the alphabet.")
+
+	var rng = NewPRNG(42)
+	var outside = func(genes []rune) (count int) {
+		for _, gene := range genes {
+			if !isNucleotide(gene) {
+				count++
+			}
+		}
+		return count
+	}
+
+	var dna = DNAFromString("AAAAAAAAAA")
+	var rates = []float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	dnaMutatePerGene(rng, &dna, rates, DNA4)
+	dnaMutateWithVariableRate(rng, &dna, 1.0, func(pos int) float32 { return 1.0 }, DNA4)
+
+	var diploid DiploidDNA
+	dnaCreateDiploid(rng, &diploid, 10, DNA4)
+	dnaMutateDiploid(rng, &diploid, 1.0, DNA4)
+
+	var adaptive SelfAdaptiveDNA
+	dnaCreateSelfAdaptive(rng, &adaptive, 10, 1.0, DNA4)
+	dnaMutateSelfAdaptive(rng, &adaptive, DNA4)
+
+	var genes = append(append(append(append([]rune{}, dna.genes...), diploid.genesA...), diploid.genesB...), adaptive.genes...)
+	if count := outside(genes); count == 0 && len(genes) == 40 {
+		fmt.Println("PASS: all", len(genes), "genes created and mutated were bases")
+	} else {
+		fmt.Println("FAIL:", count, "of", len(genes), "genes created and mutated were not bases")
+	}
+}
+
+/**
+ * Nucleotide Fitness Check
+ * Checks that only matching bases score, and that an empty target scores 0
+ * rather than NaN
+ */
+func testNucleotideFitness() {
+	fmt.Println("Checking nucleotide fitness scores matching bases.")
+
+	// Four bases match, and the matching Xs are not bases so do not score
+	var dna = DNAFromString("GATTXXCCGG")
+	if score := NucleotideFitness("GATTXXAAAA")(&dna); score == 0.4 {
+		fmt.Println("PASS: 4 matching bases of 10 scored", score)
+	} else {
+		fmt.Println("FAIL: 4 matching bases of 10 scored", score)
+	}
+
+	if score := NucleotideFitness("")(&dna); score == 0 {
+		fmt.Println("PASS: an empty target scored", score)
+	} else {
+		fmt.Println("FAIL: an empty target scored", score)
+	}
+
+	if string(DNA4Alphabet.Runes) == "ATGC" {
+		fmt.Println("PASS: DNA4Alphabet holds the bases", string(DNA4Alphabet.Runes))
+	} else {
+		fmt.Println("FAIL: DNA4Alphabet holds", string(DNA4Alphabet.Runes))
+	}
+}
+
+/**
+ * GC Content Check
+ * Checks the GC content of a known sequence, and that the GC content fitness
+ * rewards sequences nearer to the target GC content
+ */
+func testGCContent() {
+	fmt.Println("Checking the GC content of a known sequence.")
+
+	var sequence = DNAFromString("GATTACAGGC")
+	if gc := GCContent(&sequence); gc > 0.49 && gc < 0.51 {
+		fmt.Println("PASS: GATTACAGGC has a GC content of", gc)
+	} else {
+		fmt.Println("FAIL: GATTACAGGC has a GC content of", gc, "not 0.5")
+	}
+
+	var fitness = GCContentFitness(0.75)
+	var near, far = DNAFromString("GCGCGCAT"), DNAFromString("ATATATGC")
+	if fitness(&near) == 1.0 && fitness(&near) > fitness(&far) {
+		fmt.Println("PASS: a GC content of 0.75 scores", fitness(&near), "against 0.25 scoring", fitness(&far))
+	} else {
+		fmt.Println("FAIL: a GC content of 0.75 scores", fitness(&near), "against 0.25 scoring", fitness(&far))
+	}
+}
+
+/**
+ * Compression Fitness Check
+ * Checks that with both built-in compressors, a constant gene sequence scores
+ * near 1.0 and a random one below 0.25 (zlib's Huffman coding still saves a
+ * little on printable ASCII)
+ */
+func testCompressionFitness() {
+	fmt.Println("Checking constant genes compress better than random genes.")
+
+	var rng = NewPRNG(42)
+	var constant = DNAFromString(strings.Repeat("A", 1000))
+	var scrambled = DNA{}
+	dnaCreate(rng, &scrambled, 1000, PrintableASCII)
+
+	for _, compressor := range []struct {
+		name string
+		fn   func([]byte) int
+	}{{"run-length", RunLengthCompressor}, {"zlib", ZlibCompressor}} {
+		var fitness = CompressionFitness(compressor.fn)
+		if fitness(&constant) > 0.9 && fitness(&scrambled) < 0.25 {
+			fmt.Println("PASS: with", compressor.name, "constant genes scored", fitness(&constant), "and random genes", fitness(&scrambled))
+		} else {
+			fmt.Println("FAIL: with", compressor.name, "constant genes scored", fitness(&constant), "and random genes", fitness(&scrambled))
+		}
+	}
+}
+
+/**
+ * Neural Network Fitness Check
+ * Checks that on XOR, a 2-2-1 network of zero weights (outputting 0.5, so an
+ * MSE of 0.25) scores 0.8 and one with hand-set weights solving XOR scores
+ * above 0.99
+ */
+func testNeuralNetFitness() {
+	fmt.Println("Checking neural network fitness on XOR.")
+
+	var layers = []int{2, 2, 1}
+	var fitness = NeuralNetFitness(layers, [][]float32{{0, 0}, {0, 1}, {1, 0}, {1, 1}}, []float32{0, 1, 1, 0})
+
+	var zero = FloatDNA{genes: make([]float64, FloatDNALengthForNetwork(layers))}
+	if score := fitness(&zero); math.Abs(float64(score)-0.8) < 1e-6 {
+		fmt.Println("PASS: zero weights scored", score)
+	} else {
+		fmt.Println("FAIL: zero weights scored", score)
+	}
+
+	// The hidden neurons compute OR and AND, the output OR and not AND (each weight then bias)
+	var solved = FloatDNA{genes: []float64{20, 20, -10, 20, 20, -30, 20, -20, -10}}
+	if score := fitness(&solved); score > 0.99 {
+		fmt.Println("PASS: XOR weights scored", score)
+	} else {
+		fmt.Println("FAIL: XOR weights scored", score)
+	}
+}
+
+/**
+ * Float Population Evolution
+ * Evolves a float population of the given size by BLX-alpha crossover and
+ * Gaussian mutation, until its best entity is done or the generation limit is
+ * reached. Returns the best entity and the generation it was found by.
+ */
+func evolveFloatPopulation(fitness FloatFitnessFunc, bounds [][2]float64, size int, limit int, done func(best *FloatDNA) bool) (FloatDNA, int) {
+	var population = FloatPopulation{bounds: bounds, fitnessFunc: fitness, mutationRate: 0.1, sigma: 0.1, alpha: 0.5, rng: NewPRNG(42)}
+	floatPopulationSetup(&population, size)
+
+	var best = &population.entities[floatPopulationGetBest(&population)]
+	for population.generations < limit && !done(best) {
+		floatPopulationGenerate(&population)
+		best = &population.entities[floatPopulationGetBest(&population)]
+	}
+
+	return *best, population.generations
+}
+
+/**
+ * Rastrigin Convergence Check
+ * Checks that a float population of 500 finds the global optimum of the 2D
+ * Rastrigin function, to within 0.01 of xi = 0, in 2000 generations
+ */
+func testRastriginConvergence() {
+	fmt.Println("Checking a float population converges on the Rastrigin optimum.")
+
+	var near = func(best *FloatDNA) bool {
+		return math.Abs(best.genes[0]) < 0.01 && math.Abs(best.genes[1]) < 0.01
+	}
+	var best, generation = evolveFloatPopulation(RastriginFitness(2), FloatBounds(2, RastriginBounds), 500, 2000, near)
+
+	if near(&best) {
+		fmt.Println("PASS: reached", best.genes, "by generation", generation)
+	} else {
+		fmt.Println("FAIL: only reached", best.genes, "by generation", generation)
+	}
+}
+
+/**
+ * Rosenbrock Convergence Check
+ * Checks that a float population finds the global optimum of the 2D Rosenbrock
+ * function, to within 1% of xi = 1, in 5000 generations, and that DNA too short
+ * for either benchmark scores 0
+ */
+func testRosenbrockConvergence() {
+	fmt.Println("Checking a float population converges on the Rosenbrock optimum.")
+
+	var near = func(best *FloatDNA) bool {
+		return math.Abs(best.genes[0]-1) < 0.01 && math.Abs(best.genes[1]-1) < 0.01
+	}
+	var best, generation = evolveFloatPopulation(RosenbrockFitness(2), FloatBounds(2, RosenbrockBounds), 100, 5000, near)
+
+	if near(&best) {
+		fmt.Println("PASS: reached", best.genes, "by generation", generation)
+	} else {
+		fmt.Println("FAIL: only reached", best.genes, "by generation", generation)
+	}
+
+	var short = FloatDNA{genes: []float64{1}}
+	if RosenbrockFitness(2)(&short) == 0 && RastriginFitness(2)(&short) == 0 {
+		fmt.Println("PASS: DNA of 1 gene scores 0 on the 2D benchmarks")
+	} else {
+		fmt.Println("FAIL: DNA of 1 gene scored on the 2D benchmarks")
+	}
+}
+
+/**
+ * Sphere Fitness Check
+ * The regression check for basic optimization: a float population of 100 must
+ * reach a fitness above 0.99999 on the 2D sphere function within 200 generations,
+ * or crossover or mutation is broken. Also checks that the sphere function panics
+ * when created with fewer bounds than dimensions.
+ */
+func testSphereFitness2D() {
+	fmt.Println("Checking a float population optimizes the sphere function.")
+
+	var bounds = FloatBounds(2, [2]float64{-5, 5})
+	var fitness = SphereFitness(2, bounds)
+	var best, generation = evolveFloatPopulation(fitness, bounds, 100, 200, func(best *FloatDNA) bool {
+		return best.fitness > 0.99999
+	})
+
+	if best.fitness > 0.99999 {
+		fmt.Println("PASS: reached fitness", best.fitness, "by generation", generation)
+	} else {
+		fmt.Println("FAIL: only reached fitness", best.fitness, "by generation", generation)
+	}
+
+	var short = FloatDNA{genes: []float64{0}}
+	if fitness(&short) == 0 {
+		fmt.Println("PASS: DNA of 1 gene scores 0 on the 2D sphere")
+	} else {
+		fmt.Println("FAIL: DNA of 1 gene scored", fitness(&short), "on the 2D sphere")
+	}
+
+	var panicked = func() (r interface{}) {
+		defer func() { r = recover() }()
+		SphereFitness(3, bounds)
+		return nil
+	}()
+
+	if panicked != nil {
+		fmt.Println("PASS: creating the 3D sphere with 2 bounds panicked:", panicked)
+	} else {
+		fmt.Println("FAIL: creating the 3D sphere with 2 bounds did not panic")
+	}
+}
+
+/**
+ * Error Types Check
+ * Checks that invalid input to crossover, mutation and generation is reported
+ * as the corresponding error type, rather than panicking
+ */
+func testErrorTypes() {
+	fmt.Println("Checking invalid input is reported as errors.")
+
+	var rng = NewPRNG(42)
+	var returns = func(name string, fn func() error, ok func(err error) bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Println("FAIL:", name, "panicked:", r)
+			}
+		}()
+
+		if err := fn(); ok(err) {
+			fmt.Println("PASS:", name, "returned", err)
+		} else {
+			fmt.Println("FAIL:", name, "returned", err)
+		}
+	}
+
+	var isLengthMismatch = func(err error) bool {
+		var _, ok = err.(ErrGeneLengthMismatch)
+		return ok
+	}
+
+	returns("crossover of 3 and 4 genes", func() error {
+		var a, b = DNAFromString("abc"), DNAFromString("abcd")
+		var _, err = dnaCrossover(rng, &a, &b)
+		return err
+	}, isLengthMismatch)
+
+	returns("mutation of 3 genes by 2 rates", func() error {
+		var a = DNAFromString("abc")
+		return dnaMutatePerGene(rng, &a, []float32{0.1, 0.1}, PrintableASCII)
+	}, isLengthMismatch)
+
+	returns("generation from an empty mating pool", func() error {
+		var population = Population{cfg: Config{Target: target, MaxPop: 10, MutationRate: mutrate, CrossoverRate: 1.0}, perfectScore: 1.0}
+		setup(&population)
+		population.matingPool = nil
+		return populationGenerate(&population)
+	}, func(err error) bool {
+		var _, ok = err.(ErrEmptyMatingPool)
+		return ok
+	})
+}
+
+/**
+ * Invalid Config Check
+ * Checks that creating a population with an invalid config returns
+ * ErrInvalidConfig and no population, rather than panicking
+ */
+func testInvalidConfigError() {
+	fmt.Println("Checking invalid configs are reported as errors.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 0, MutationRate: mutrate, CrossoverRate: 1.0})
+	var _, isInvalid = err.(ErrInvalidConfig)
+
+	if isInvalid && population == nil {
+		fmt.Println("PASS: a population of 0 returned ErrInvalidConfig")
+	} else {
+		fmt.Println("FAIL: a population of 0 did not return ErrInvalidConfig")
+	}
+}
+
+/**
+ * Validate Config Check
+ * Checks each validation rule independently, either side of its boundary
+ */
+func testValidateConfig() {
+	fmt.Println("Checking each config validation rule at its boundaries.")
+
+	var cases = []struct {
+		name   string
+		change func(c *Config)
+		field  string // The field reported invalid, or "" if the config is valid
+	}{
+		{"MaxPop = 1", func(c *Config) { c.MaxPop = 1 }, "MaxPop"},
+		{"MaxPop = 2", func(c *Config) { c.MaxPop = 2 }, ""},
+		{"MutationRate = -0.001", func(c *Config) { c.MutationRate = -0.001 }, "MutationRate"},
+		{"MutationRate = 0.0", func(c *Config) { c.MutationRate = 0.0 }, ""},
+		{"MutationRate = 1.0", func(c *Config) { c.MutationRate = 1.0 }, ""},
+		{"MutationRate = 1.001", func(c *Config) { c.MutationRate = 1.001 }, "MutationRate"},
+		{"CrossoverRate = -0.001", func(c *Config) { c.CrossoverRate = -0.001 }, "CrossoverRate"},
+		{"CrossoverRate = 0.0", func(c *Config) { c.CrossoverRate = 0.0 }, ""},
+		{"CrossoverRate = 1.001", func(c *Config) { c.CrossoverRate = 1.001 }, "CrossoverRate"},
+		{"Target = \"\"", func(c *Config) { c.Target = "" }, "Target"},
+		{"Target = \"a\"", func(c *Config) { c.Target = "a" }, ""},
+		{"EliteCount = -1", func(c *Config) { c.EliteCount = -1 }, "EliteCount"},
+		{"EliteCount = 0", func(c *Config) { c.EliteCount = 0 }, ""},
+		{"EliteCount = MaxPop - 1", func(c *Config) { c.EliteCount = c.MaxPop - 1 }, ""},
+		{"EliteCount = MaxPop", func(c *Config) { c.EliteCount = c.MaxPop }, "EliteCount"},
+		{"GenerationalGap = -0.001", func(c *Config) { c.GenerationalGap = -0.001 }, "GenerationalGap"},
+		{"GenerationalGap = 1.0", func(c *Config) { c.GenerationalGap = 1.0 }, ""},
+		{"GenerationalGap = 1.001", func(c *Config) { c.GenerationalGap = 1.001 }, "GenerationalGap"},
+	}
+
+	var failed int
+	for _, tc := range cases {
+		var cfg = Config{Target: target, MaxPop: 10, MutationRate: mutrate, CrossoverRate: 1.0}
+		tc.change(&cfg)
+
+		var field string
+		if err, ok := validateConfig(&cfg).(ErrInvalidConfig); ok {
+			field = err.Field
+		}
+		if field != tc.field {
+			fmt.Printf("FAIL: %s reported the field %q invalid, not %q\n", tc.name, field, tc.field)
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		fmt.Println("PASS: all", len(cases), "configs were validated correctly")
+	}
+}
+
+/**
+ * Clone Check
+ * Checks that changing the genes of a clone, or of a child after crossover,
+ * leaves the original and the parents unchanged
+ */
+func testClone() {
+	fmt.Println("Checking clones and children do not alias their genes.")
+
+	var rng = NewPRNG(42)
+	var original = DNAFromString("genetic")
+	var clone = original.Clone()
+	clone.genes[0] = 'G'
+
+	if dnaExtractPhrase(&original) == "genetic" && dnaExtractPhrase(&clone) == "Genetic" {
+		fmt.Println("PASS: changing a clone left the original unchanged")
+	} else {
+		fmt.Println("FAIL: changing a clone changed the original to", dnaExtractPhrase(&original))
+	}
+
+	var a, b = DNAFromString("aaaaaaa"), DNAFromString("bbbbbbb")
+	var child, err = dnaCrossover(rng, &a, &b)
+	if err != nil {
+		fmt.Println("FAIL: could not cross over parents:", err)
+		return
+	}
+	dnaMutate(rng, &child, 1.0, Binary)
+
+	if dnaExtractPhrase(&a) == "aaaaaaa" && dnaExtractPhrase(&b) == "bbbbbbb" {
+		fmt.Println("PASS: mutating a child left its parents unchanged")
+	} else {
+		fmt.Println("FAIL: mutating a child changed its parents to", dnaExtractPhrase(&a), "and", dnaExtractPhrase(&b))
+	}
+}
+
+/**
+ * Packed Binary Crossover Check
+ * Checks that crossing over packed binary DNA of the same length breeds a child
+ * of that length whose every bit comes from one of its parents, and that
+ * partners of differing length are rejected with ErrGeneLengthMismatch
+ */
+func testPackedBinaryCrossover() {
+	fmt.Println("Checking packed binary crossover.")
+
+	var rng = NewPRNG(42)
+	var partnerA, partnerB = PackedBinaryDNACreate(rng, 130), PackedBinaryDNACreate(rng, 130)
+
+	var child, err = PackedBinaryDNACrossover(rng, &partnerA, &partnerB)
+	var inherited = err == nil && child.Len() == 130
+	for i := 0; inherited && i < child.Len(); i++ {
+		inherited = child.getBit(i) == partnerA.getBit(i) || child.getBit(i) == partnerB.getBit(i)
+	}
+
+	if inherited {
+		fmt.Println("PASS: child of 130 bits inherited every bit from its parents")
+	} else {
+		fmt.Println("FAIL: child of", child.Len(), "bits did not inherit every bit from its parents, error:", err)
+	}
+
+	var shorter = PackedBinaryDNACreate(rng, 70)
+	_, err = PackedBinaryDNACrossover(rng, &partnerA, &shorter)
+	if _, ok := err.(ErrGeneLengthMismatch); ok {
+		fmt.Println("PASS: crossing over 130 and 70 bits returned", err)
+	} else {
+		fmt.Println("FAIL: crossing over 130 and 70 bits returned", err)
+	}
+}
+
+/**
+ * Dynamic Population Sizing Check
+ * Checks that resizing a population of one large species and two under-sized
+ * species grows each species to the target size, without exceeding MaxPop
+ */
+func testDynamicPopulationSizing() {
+	fmt.Println("Checking dynamic population sizing grows under-sized species.")
+
+	var population = &Population{cfg: Config{Target: "aaaaaaaaaa", MaxPop: 36, SpeciesDistance: 2}, perfectScore: 1.0, rng: NewPRNG(42)}
+	for _, family := range []struct {
+		phrase string
+		size   int
+	}{{"aaaaaaaaaa", 30}, {"zzzzzzzzzz", 2}, {"mmmmmmmmmm", 1}} {
+		for i := 0; i < family.size; i++ {
+			population.entities = append(population.entities, DNAFromString(family.phrase))
+		}
+	}
+
+	DynamicPopulationSizing(population, 5)
+
+	var sizes []int
+	var undersized int
+	for _, members := range populationSpeciate(population) {
+		sizes = append(sizes, len(members))
+		if len(members) < 5 {
+			undersized++
+		}
+	}
+
+	if undersized == 0 && len(sizes) == 3 && len(population.entities) <= population.cfg.MaxPop {
+		fmt.Println("PASS: species sizes", sizes, "are all at least 5, totalling", len(population.entities), "of at most", population.cfg.MaxPop)
+	} else {
+		fmt.Println("FAIL: species sizes", sizes, "total", len(population.entities), "of at most", population.cfg.MaxPop)
+	}
+}
+
+/**
+ * Island Crossover Check
+ * Checks that two identically initialized islands, which neither mutate nor
+ * cross over on their own, only breed entities neither started with when
+ * inter-island crossover is applied over 50 generations
+ */
+func testIslandCrossover() {
+	fmt.Println("Checking identical islands diverge after inter-island crossover.")
+
+	var newcomers = func(rate float32) (int, error) {
+		var first, err = NewPopulation(Config{Target: target, MaxPop: 200, MutationRate: 0.0, CrossoverRate: 0.0})
+		if err != nil {
+			return 0, err
+		}
+
+		// The second island starts as a copy of the first
+		var second = &Population{cfg: first.cfg, perfectScore: first.perfectScore, CacheDirty: true}
+		var initial = make(map[string]bool)
+		for i := range first.entities {
+			second.entities = append(second.entities, first.entities[i].Clone())
+			initial[dnaExtractPhrase(&first.entities[i])] = true
+		}
+
+		// The islands may converge, so newcomers are counted every generation
+		var model = IslandModel{Islands: []*Population{first, second}, Topology: RingTopology, InterIslandCrossoverRate: rate}
+		var bred = make(map[string]bool)
+		for generation := 0; generation < 50; generation++ {
+			if err := model.Evolve(); err != nil {
+				return 0, err
+			}
+
+			for _, island := range model.Islands {
+				for i := range island.entities {
+					if phrase := dnaExtractPhrase(&island.entities[i]); !initial[phrase] {
+						bred[phrase] = true
+					}
+				}
+			}
+		}
+		return len(bred), nil
+	}
+
+	var apart, err = newcomers(0.0)
+	var crossed, crossedErr = newcomers(1.0)
+
+	if err == nil && crossedErr == nil && apart == 0 && crossed > 0 {
+		fmt.Println("PASS: islands bred", crossed, "new entities with inter-island crossover, and", apart, "without")
+	} else {
+		fmt.Println("FAIL: islands bred", crossed, "new entities with inter-island crossover, and", apart, "without, errors:", err, crossedErr)
+	}
+
+	// A crossed over child is a new entity, descended from an entity of each island
+	var islands = make([]*Population, 2)
+	var initialIDs = make(map[int64]bool)
+	for i := range islands {
+		if islands[i], err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: 0.0, CrossoverRate: 0.0, Seed: int64(i + 1)}); err != nil {
+			fmt.Println("FAIL: could not create island:", err)
+			return
+		}
+		for j := range islands[i].entities {
+			initialIDs[islands[i].entities[j].ID] = true
+		}
+	}
+
+	err = IslandCrossover(NewPRNG(42), islands, RingTopology)
+
+	var children, descended = 0, 0
+	for _, island := range islands {
+		for i := range island.entities {
+			var entity = &island.entities[i]
+			if initialIDs[entity.ID] {
+				continue
+			}
+			children++
+			if initialIDs[entity.ParentIDs[0]] && initialIDs[entity.ParentIDs[1]] {
+				descended++
+			}
+		}
+	}
+
+	if err == nil && children == 1 && descended == 1 {
+		fmt.Println("PASS: island crossover child has a new ID and its parents' IDs")
+	} else {
+		fmt.Println("FAIL: island crossover bred", children, "children with new IDs,", descended, "with their parents' IDs, error:", err)
+	}
+}
+
+/**
+ * Sexual Selection Distance Check
+ * Checks that after 20 generations of choosy mating, the average pairwise
+ * distance between entities is higher than after random pairing
+ */
+func testSexualSelectionDistance() {
+	fmt.Println("Checking choosy mating keeps entities further apart than random pairing.")
+
+	var averageDistance = func(choosiness float32) (float64, error) {
+		var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, Choosiness: choosiness})
+		if err != nil {
+			return 0, err
+		}
+		for i := 0; i < 20; i++ {
+			if err = evolve(population); err != nil {
+				return 0, err
+			}
+		}
+
+		var total, pairs int
+		for i := range population.entities {
+			for j := i + 1; j < len(population.entities); j++ {
+				total += HammingDistance(&population.entities[i], &population.entities[j])
+				pairs++
+			}
+		}
+		return float64(total) / float64(pairs), nil
+	}
+
+	var random, err = averageDistance(0)
+	var choosy, choosyErr = averageDistance(0.9)
+
+	if err == nil && choosyErr == nil && choosy > random {
+		fmt.Println("PASS: entities were", choosy, "apart with choosy mating, and", random, "with random pairing")
+	} else {
+		fmt.Println("FAIL: entities were", choosy, "apart with choosy mating, and", random, "with random pairing, errors:", err, choosyErr)
+	}
+}
+
+/**
+ * Step Limit Context
+ * A context for the coevolution check which is done after limit checks of Err,
+ * calling onCheck before each
+ */
+type stepLimitContext struct {
+	context.Context
+	limit   int
+	onCheck func()
+}
+
+/**
+ * Step Limit Context: Err
+ * Counts down the checks, returning context.Canceled once there are none left
+ */
+func (c *stepLimitContext) Err() error {
+	c.onCheck()
+	if c.limit--; c.limit < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+/**
+ * Coevolution Pair Check
+ * Checks that evolving a host/parasite pair changes both populations, that
+ * neither stagnates, still changing in the last 10 of 50 generations, and that
+ * each generation is evolved as evolve does, timed in the population's timer
+ */
+func testCoevolutionPair() {
+	fmt.Println("Checking coevolved populations both keep changing.")
+
+	var hosts, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+	parasites, err := NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var hostHistory, parasiteHistory []string
+	var ctx = &stepLimitContext{Context: context.Background(), limit: 50, onCheck: func() {
+		hostHistory = append(hostHistory, PopulationAllPhrases(hosts, 0))
+		parasiteHistory = append(parasiteHistory, PopulationAllPhrases(parasites, 0))
+	}}
+
+	var pair = CoevolutionPair{HostPopulation: hosts, ParasitePopulation: parasites, InteractionFn: FirstLastMatchInteraction}
+	if err = pair.Evolve(ctx); err != context.Canceled {
+		fmt.Println("FAIL: coevolution stopped with", err)
+		return
+	}
+
+	var changes = func(history []string) (total, late int) {
+		for i := 1; i < len(history); i++ {
+			if history[i] != history[i-1] {
+				total++
+				if i > len(history)-10 {
+					late++
+				}
+			}
+		}
+		return total, late
+	}
+	var hostChanges, hostLate = changes(hostHistory)
+	var parasiteChanges, parasiteLate = changes(parasiteHistory)
+
+	if hostLate > 0 && parasiteLate > 0 {
+		fmt.Println("PASS: hosts changed in", hostChanges, "and parasites in", parasiteChanges, "of 50 generations, both in the last 10")
+	} else {
+		fmt.Println("FAIL: hosts changed in", hostChanges, "and parasites in", parasiteChanges, "of 50 generations,", hostLate, "and", parasiteLate, "times in the last 10")
+	}
+
+	if hosts.generations > 0 && hosts.Timer.Generations == hosts.generations && parasites.Timer.Generations == parasites.generations {
+		fmt.Println("PASS: both timers recorded all", hosts.generations, "generations")
+	} else {
+		fmt.Println("FAIL: the timers recorded", hosts.Timer.Generations, "and", parasites.Timer.Generations, "of", hosts.generations, "generations")
+	}
+}
+
+/**
+ * Natural Selection Check
+ * Checks the mating pool is filled in proportion to fitness, with one entity at
+ * fitness 1.0 and nine at 0.0 making up at least 80% of the pool, and that a
+ * population of equally fit entities still fills the pool
+ */
+func testNaturalSelection() {
+	fmt.Println("Checking natural selection fills the mating pool in proportion to fitness.")
+
+	var population = Population{}
+	for i := 0; i < 10; i++ {
+		population.entities = append(population.entities, DNA{genes: []rune{rune('0' + i)}})
+	}
+	population.entities[0].fitness = 1.0
+
+	populationNaturalSelection(&population)
+
+	var copies int
+	for _, dna := range population.matingPool {
+		if dna.genes[0] == '0' {
+			copies++
+		}
+	}
+
+	if len(population.matingPool) > 0 && float32(copies)/float32(len(population.matingPool)) >= 0.8 {
+		fmt.Println("PASS: fittest entity makes up", copies, "of", len(population.matingPool), "mating pool entries")
+	} else {
+		fmt.Println("FAIL: fittest entity makes up", copies, "of", len(population.matingPool), "mating pool entries")
+	}
+
+	for i := range population.entities {
+		population.entities[i].fitness = 0.5
+	}
+
+	populationNaturalSelection(&population)
+
+	if len(population.matingPool) > 0 {
+		fmt.Println("PASS: equal fitness mating pool has", len(population.matingPool), "entries")
+	} else {
+		fmt.Println("FAIL: equal fitness mating pool is empty")
+	}
+}
+
+/**
+ * Zero Fitness Check
+ * Checks that a population in which every entity has zero fitness can still
+ * breed a new generation, rather than panicking on an empty mating pool
+ */
+func testZeroFitness() {
+	fmt.Println("Checking a population with all-zero fitness can evolve.")
+
+	var population = Population{cfg: Config{Target: "abc", MaxPop: 10, CrossoverRate: 1.0}}
+	for i := 0; i < population.cfg.MaxPop; i++ {
+		population.entities = append(population.entities, DNAFromString("xyz"))
+	}
+
+	var ok = testRecover(func() bool {
+		populationNaturalSelection(&population)
+		return len(population.matingPool) == len(population.entities) && populationGenerate(&population) == nil
+	})
+
+	if ok {
+		fmt.Println("PASS: all-zero fitness population evolved")
+	} else {
+		fmt.Println("FAIL: all-zero fitness population could not evolve")
+	}
+}
+
+/**
+ * Empty Mating Pool Check
+ * Checks that a selector producing an empty mating pool causes evolve to return
+ * ErrEmptyMatingPool, rather than panicking
+ */
+func testEmptyMatingPool() {
+	fmt.Println("Checking an empty mating pool is reported as an error.")
+
+	var population = Population{cfg: Config{Target: "abc", MaxPop: 10, CrossoverRate: 1.0}}
+	for i := 0; i < population.cfg.MaxPop; i++ {
+		population.entities = append(population.entities, DNAFromString("xyz"))
+	}
+	population.cfg.Selector = SelectorFunc(func(population *Population) {
+		population.matingPool = nil
+	})
+
+	var ok = testRecover(func() bool {
+		var _, isEmptyPool = evolve(&population).(ErrEmptyMatingPool)
+		return isEmptyPool
+	})
+
+	if ok {
+		fmt.Println("PASS: empty mating pool returned ErrEmptyMatingPool")
+	} else {
+		fmt.Println("FAIL: empty mating pool did not return ErrEmptyMatingPool")
+	}
+}
+
+/**
+ * Gene Length Mismatch Check
+ * Checks that assessing dna shorter than the target, and crossing over parents
+ * of different lengths, return ErrGeneLengthMismatch rather than panicking
+ */
+func testGeneLengthMismatch() {
+	fmt.Println("Checking gene length mismatches are reported as errors.")
+
+	var rng = NewPRNG(42)
+
+	var ok = testRecover(func() bool {
+		var short = DNAFromString("I think")
+		var _, assessMismatch = dnaAssessFitness(&short, target).(ErrGeneLengthMismatch)
+
+		var long = DNAFromString(target)
+		var _, err = dnaCrossover(rng, &short, &long)
+		var _, crossoverMismatch = err.(ErrGeneLengthMismatch)
+
+		return assessMismatch && crossoverMismatch
+	})
+
+	if ok {
+		fmt.Println("PASS: gene length mismatches returned ErrGeneLengthMismatch")
+	} else {
+		fmt.Println("FAIL: gene length mismatches did not return ErrGeneLengthMismatch")
+	}
+}
+
+/**
+ * Run N Check
+ * Checks that RunN adds exactly n generations to a population that cannot
+ * converge in that many steps
+ */
+func testRunN() {
+	fmt.Println("Checking RunN evolves a fixed number of generations.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 10, MutationRate: 0, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var before = population.generations
+	err = RunN(context.Background(), 5, population)
+
+	if err == nil && population.generations-before == 5 {
+		fmt.Println("PASS: RunN evolved exactly 5 generations")
+	} else {
+		fmt.Println("FAIL: RunN evolved", population.generations-before, "generations, error:", err)
+	}
+}
+
+/**
+ * Top K Check
+ * Checks that PopulationTopK and PopulationBottomK return k sorted entities, and
+ * that the first of the top k is the population's best
+ */
+func testTopK() {
+	fmt.Println("Checking the top and bottom k entities of a population.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var top = PopulationTopK(population, 5)
+	var bottom = PopulationBottomK(population, 5)
+
+	var ok = len(top) == 5 && len(bottom) == 5 &&
+		dnaExtractPhrase(&top[0]) == populationGetBest(population)
+	for i := 1; i < len(top) && ok; i++ {
+		ok = top[i-1].fitness >= top[i].fitness && bottom[i-1].fitness <= bottom[i].fitness
+	}
+
+	if ok {
+		fmt.Println("PASS: top and bottom 5 are sorted and led by the best entity")
+	} else {
+		fmt.Println("FAIL: top and bottom 5 are not sorted or not led by the best entity")
+	}
+}
+
+/**
+ * Hamming Distance Check
+ * Checks HammingDistance against a table of dna pairs
+ */
+func testHammingDistance() {
+	fmt.Println("Checking Hamming distances between dna.")
+
+	var cases = []struct {
+		name     string
+		a, b     string
+		distance int
+	}{
+		{"equal", "genetic", "genetic", 0},
+		{"completely different", "abc", "xyz", 3},
+		{"partial match", "genetic", "generic", 1},
+		{"length mismatch", "gene", "genetic", -1},
+	}
+
+	for _, c := range cases {
+		var a, b = DNAFromString(c.a), DNAFromString(c.b)
+		if distance := HammingDistance(&a, &b); distance == c.distance {
+			fmt.Println("PASS:", c.name, "distance is", distance)
+		} else {
+			fmt.Println("FAIL:", c.name, "distance is", distance, "expected", c.distance)
+		}
+	}
+}
+
+/**
+ * All Phrases Check
+ * Checks that PopulationAllPhrases honours its limit, and lists every entity
+ * when the limit is 0
+ */
+func testAllPhrases() {
+	fmt.Println("Checking the phrase listing limit.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 250, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var limited = len(strings.Split(PopulationAllPhrases(population, 3), "\n"))
+	var all = len(strings.Split(PopulationAllPhrases(population, 0), "\n"))
+
+	if limited == 3 && all == 250 {
+		fmt.Println("PASS: limit 3 listed 3 phrases and limit 0 listed all 250")
+	} else {
+		fmt.Println("FAIL: limit 3 listed", limited, "phrases and limit 0 listed", all)
+	}
+}
+
+/**
+ * Evolve Until Check
+ * Checks that EvolveUntil stops once each of the convenience conditions is met
+ */
+func testEvolveUntil() {
+	fmt.Println("Checking EvolveUntil stops on each condition.")
+
+	var cfg = Config{Target: "genetic", MaxPop: 100, MutationRate: 0.01, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, Seed: 42}
+
+	var conditions = []struct {
+		name      string
+		condition func(*Population) bool
+		met       func(*Population) bool
+	}{
+		{"generation 4 reached", UntilGenerationReached(4), func(p *Population) bool { return p.generations == 4 }},
+		{"fitness above 0.5", UntilFitnessAbove(0.5), func(p *Population) bool { return p.bestFitness > 0.5 }},
+		{"solved", UntilSolved(), func(p *Population) bool { return populationGetBest(p) == cfg.Target }},
+	}
+
+	for _, c := range conditions {
+		var population, err = NewPopulation(cfg)
+		if err == nil {
+			err = EvolveUntil(context.Background(), c.condition, population)
+		}
+
+		if err == nil && c.met(population) {
+			fmt.Println("PASS: evolved until", c.name, "at generation", population.generations)
+		} else {
+			fmt.Println("FAIL: evolving until", c.name, "stopped at generation", population.generations, "error:", err)
+		}
+	}
+}
+
+/**
+ * Pick Parents Check
+ * Checks that a mating pool of two distinct entities never has an entity picked
+ * to cross with itself
+ */
+func testPickParents() {
+	fmt.Println("Checking parents are never crossed with themselves.")
+
+	var population = Population{matingPool: []DNA{{genes: []rune("aaa")}, {genes: []rune("bbb")}}}
+
+	var selfCrossed int
+	for i := 0; i < 1000; i++ {
+		if a, b := populationPickParents(&population); a == b {
+			selfCrossed++
+		}
+	}
+
+	if selfCrossed == 0 {
+		fmt.Println("PASS: no entity was picked to cross with itself")
+	} else {
+		fmt.Println("FAIL:", selfCrossed, "of 1000 picks crossed an entity with itself")
+	}
+}
+
+/**
+ * Restart Check
+ * Checks that restarting a fully converged population restores its diversity
+ * while preserving its elite entity, that the restarted entities are new ones,
+ * and that an elite larger than the population is kept whole
+ */
+func testRestart() {
+	fmt.Println("Checking a converged population can be restarted.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, EliteCount: 1, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	// Converge on a near miss, with a single elite one gene better than the rest,
+	// all of them old clones
+	var elite = "I think, therefore I am!"
+	for i := range population.entities {
+		population.entities[i] = DNAFromString("I think, therefore I an!")
+		population.entities[i].Age, population.entities[i].ReproductionMode = 5, ClonalMode
+		population.entities[i].ID, population.entities[i].ParentIDs = int64(i+1), [2]int64{1, 2}
+	}
+	population.entities[0].genes = []rune(elite)
+	populationCalculateFitness(population, target)
+
+	RestartPopulation(population)
+
+	var preserved bool
+	var renewed, ids = 0, make(map[int64]bool)
+	for i := range population.entities {
+		var entity = &population.entities[i]
+		if dnaExtractPhrase(entity) == elite {
+			preserved = true
+			continue
+		}
+		if entity.Age == 0 && entity.ReproductionMode == CrossoverMode && entity.ParentIDs == [2]int64{} && entity.ID > int64(len(population.entities)) {
+			renewed++
+		}
+		ids[entity.ID] = true
+	}
+
+	if unique := PopulationUniqueCount(population); unique >= 95 && preserved {
+		fmt.Println("PASS: restarted population has", unique, "unique entities and kept its elite")
+	} else {
+		fmt.Println("FAIL: restarted population has", unique, "unique entities, elite kept:", preserved)
+	}
+
+	if renewed == 99 && len(ids) == 99 {
+		fmt.Println("PASS: all 99 restarted entities are new, with fresh IDs and no age or parents")
+	} else {
+		fmt.Println("FAIL:", renewed, "of 99 restarted entities are new, with", len(ids), "distinct IDs")
+	}
+
+	// An elite larger than the population keeps every entity
+	population.cfg.EliteCount = 200
+	var before = PopulationAllPhrases(population, 0)
+	var whole = testRecover(func() bool {
+		RestartPopulation(population)
+		return PopulationAllPhrases(population, 0) == before
+	})
+
+	if whole {
+		fmt.Println("PASS: an elite of 200 kept the population of 100 whole")
+	} else {
+		fmt.Println("FAIL: an elite of 200 did not keep the population of 100 whole")
+	}
+}
+
+/**
+ * Multi Target Check
+ * Checks that a population with two acceptable targets is solved by whichever
+ * it finds first
+ */
+func testMultiTarget() {
+	fmt.Println("Checking a population can solve either of two targets.")
+
+	var fitness = MultiTargetFitness{Targets: []string{"cat", "dog"}}
+	var population, err = NewPopulation(Config{Target: "cat", MaxPop: 100, MutationRate: 0.01, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, FitnessFunc: fitness.Assess, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	err = RunWithContext(context.Background(), population)
+
+	if solved := SolvedTarget(population); err == nil && (solved == "cat" || solved == "dog") {
+		fmt.Println("PASS: population solved target", solved)
+	} else {
+		fmt.Println("FAIL: population solved target", solved, "error:", err)
+	}
+}
+
+/**
+ * Migrate and Inject Check
+ * Checks that entities exported with MigrateTopK are independent copies, and
+ * that entities injected with InjectEntities join the population
+ */
+func testMigrateInject() {
+	fmt.Println("Checking entities can be exported from and injected into a population.")
+
+	var rng = NewPRNG(42)
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var before = populationGetBest(population)
+	var exported = MigrateTopK(population, 3)
+	dnaMutate(rng, &exported[0], 1.0, PrintableASCII)
+
+	if populationGetBest(population) == before {
+		fmt.Println("PASS: mutating exported entities left the population unmodified")
+	} else {
+		fmt.Println("FAIL: mutating exported entities modified the population")
+	}
+
+	var migrant = DNAFromString(target)
+	migrant.ID = dnaNewID()
+	InjectEntities(population, []DNA{migrant})
+	populationCalculateFitness(population, target)
+
+	if populationGetBest(population) == target && len(population.entities) == 20 {
+		fmt.Println("PASS: injected entity appears in the population")
+	} else {
+		fmt.Println("FAIL: injected entity does not appear in the population")
+	}
+
+	var injected = &DNA{}
+	for i := range population.entities {
+		if dnaExtractPhrase(&population.entities[i]) == target {
+			injected = &population.entities[i]
+		}
+	}
+	if injected.ID != 0 && injected.ID != migrant.ID && injected.ParentIDs == [2]int64{migrant.ID, 0} {
+		fmt.Println("PASS: injected entity has a new ID and descends from its migrant")
+	} else {
+		fmt.Println("FAIL: injected entity has ID", injected.ID, "and parents", injected.ParentIDs, "for migrant", migrant.ID)
+	}
+}
+
+/**
+ * Generational Archive Check
+ * Checks that an archive wired to the generation hook keeps only the latest
+ * MaxSize generations, in order
+ */
+func testGenerationalArchive() {
+	fmt.Println("Checking the generational archive records each generation's best.")
+
+	var archive = GenerationalArchive{MaxSize: 5}
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, OnGenerationEnd: archive.Record, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	err = RunN(context.Background(), 8, population)
+
+	var history = archive.ImprovementHistory()
+	var latest = archive.BestAtGeneration(7)
+	var ok = err == nil && len(history) == 5 &&
+		len(archive.BestAtGeneration(2).genes) == 0 &&
+		latest.fitness == history[4] && dnaExtractPhrase(&latest) == populationGetBest(population) &&
+		archive.FirstGenerationAbove(-1) == 3
+
+	if ok {
+		fmt.Println("PASS: archive holds generations 3 to 7")
+	} else {
+		fmt.Println("FAIL: archive holds", len(history), "generations, error:", err)
+	}
+}
+
+/**
+ * Compare Runs Check
+ * Checks the statistics CompareRuns aggregates from recorders with known values
+ */
+func testCompareRuns() {
+	fmt.Println("Checking run comparison statistics.")
+
+	var comparison = CompareRuns([]*PopulationRecorder{
+		{BestFitness: []float32{0.5, 1}, Generations: 40, Completed: true},
+		{BestFitness: []float32{1}, Generations: 50, Completed: true},
+		{BestFitness: []float32{0.5, 0.75}, Generations: 100},
+	})
+
+	var ok = comparison.MeanGenerationsToSolution == 45 && comparison.StdDevGenerations == 5 &&
+		math.Abs(float64(comparison.SuccessRate)-2.0/3.0) < 1e-6 &&
+		math.Abs(float64(comparison.MeanBestFitness)-2.75/3.0) < 1e-6
+
+	if ok {
+		fmt.Println("PASS: runs solved in", comparison.MeanGenerationsToSolution, "±", comparison.StdDevGenerations, "generations with", comparison.SuccessRate*100, "% success")
+	} else {
+		fmt.Println("FAIL: unexpected run comparison", comparison)
+	}
+}
+
+/**
+ * Tournament Without Replacement Check
+ * Checks that a tournament between every entity, picked without replacement,
+ * is always won by the fittest
+ */
+func testTournamentWithoutReplacement() {
+	fmt.Println("Checking tournaments without replacement.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	populationGetBest(population)
+	var selector = TournamentSelector{Size: len(population.entities)}
+	selector.Select(population)
+
+	var lost int
+	for i := range population.matingPool {
+		if population.matingPool[i].fitness != population.bestFitness {
+			lost++
+		}
+	}
+
+	if lost == 0 {
+		fmt.Println("PASS: every full tournament was won by the best entity")
+	} else {
+		fmt.Println("FAIL:", lost, "full tournaments were not won by the best entity")
+	}
+}
+
+/**
+ * Linear Rank Check
+ * Checks the rank probabilities at either end of the selective pressure range,
+ * and that the selector fills a mating pool the size of the population
+ */
+func testLinearRank() {
+	fmt.Println("Checking linear rank selection probabilities.")
+
+	const n = 10
+	var equal, pressured = linearRankProbabilities(n, 1.0), linearRankProbabilities(n, 2.0)
+
+	var uniform = true
+	for _, p := range equal {
+		uniform = uniform && math.Abs(p-1.0/n) < 1e-9
+	}
+
+	if uniform {
+		fmt.Println("PASS: at SP 1.0 every rank is equally likely")
+	} else {
+		fmt.Println("FAIL: at SP 1.0 ranks are not equally likely:", equal)
+	}
+
+	// At SP 2.0 the best is twice as likely as the average entity, and the worst is never picked
+	if math.Abs(pressured[n-1]-2.0/n) < 1e-9 && math.Abs(pressured[0]) < 1e-9 {
+		fmt.Println("PASS: at SP 2.0 the best rank has twice the average probability")
+	} else {
+		fmt.Println("FAIL: at SP 2.0 the best and worst ranks have probabilities", pressured[n-1], "and", pressured[0])
+	}
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Selector: &LinearRankSelector{SP: 1.5}, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+	populationSelect(population)
+
+	if len(population.matingPool) == len(population.entities) {
+		fmt.Println("PASS: linear rank selection filled a mating pool of", len(population.matingPool))
+	} else {
+		fmt.Println("FAIL: linear rank selection filled a mating pool of", len(population.matingPool))
+	}
+}
+
+/**
+ * Genetic Programming Check
+ * Checks that an expression tree encodes x² + 2x + 1, and that crossing over
+ * two random trees produces a valid tree
+ */
+func testGP() {
+	fmt.Println("Checking genetic programming expression trees.")
+
+	var x = &GPNode{Op: "x"}
+	var node = func(op string, children ...*GPNode) *GPNode {
+		return &GPNode{Op: op, Arity: len(children), Children: children}
+	}
+	var constant = func(value float64) *GPNode {
+		return &GPNode{Op: "const", Value: value}
+	}
+
+	// (x * x) + ((2 * x) + 1)
+	var quadratic = node("+", node("*", x, x), node("+", node("*", constant(2), x), constant(1)))
+
+	var encoded = gpValid(quadratic)
+	for _, v := range []float64{-3, -1, 0, 0.5, 2, 10} {
+		encoded = encoded && GPEvaluate(quadratic, v) == v*v+2*v+1
+	}
+
+	if encoded {
+		fmt.Println("PASS: tree encodes x² + 2x + 1")
+	} else {
+		fmt.Println("FAIL: tree does not encode x² + 2x + 1")
+	}
+
+	var rng = NewPRNG(42)
+	var ops = []string{"+", "-", "*", "/"}
+	var terminals = []float64{1, 2, 3}
+	var valid = true
+	for i := 0; i < 100; i++ {
+		var a, b = GPCreate(rng, 4, ops, terminals), GPCreate(rng, 4, ops, terminals)
+		var child = GPCrossoverSubtree(rng, &a, &b)
+		GPMutate(rng, &child, 0.5)
+		valid = valid && gpValid(child.root) && gpValid(a.root) && gpValid(b.root)
+	}
+
+	if valid {
+		fmt.Println("PASS: crossover and mutation of valid trees produced valid trees")
+	} else {
+		fmt.Println("FAIL: crossover or mutation produced an invalid tree")
+	}
+}
+
+/**
+ * Decision Tree Check
+ * Checks that a depth-1 decision tree evolves to classify linearly separable
+ * 2D data with over 90% accuracy within 500 generations
+ */
+func testDecisionTree() {
+	fmt.Println("Checking a decision tree can be evolved to classify data.")
+
+	// Points on a grid, classed by which side of x = 0.55 they fall
+	var X [][]float32
+	var y []int
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 4; j++ {
+			X = append(X, []float32{float32(i) / 10, float32(j) / 4})
+			if float32(i)/10 > 0.55 {
+				y = append(y, 1)
+			} else {
+				y = append(y, 0)
+			}
+		}
+	}
+
+	var population, err = NewPopulation(Config{
+		Target:        strings.Repeat("?", DecisionTreeGeneLength(1)),
+		MaxPop:        50,
+		MutationRate:  0.05,
+		CrossoverRate: 1.0,
+		Alphabet:      DecisionTreeAlphabet,
+		FitnessFunc:   DecisionTreeFitness(X, y),
+		Seed:          42,
+	})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var solvedOrDone = func(p *Population) bool {
+		return UntilSolved()(p) || UntilGenerationReached(500)(p)
+	}
+	err = EvolveUntil(context.Background(), solvedOrDone, population)
+
+	if populationGetBest(population); err == nil && population.bestFitness > 0.9 {
+		fmt.Println("PASS: decision tree reached", population.bestFitness*100, "% accuracy by generation", population.generations)
+	} else {
+		fmt.Println("FAIL: decision tree reached", population.bestFitness*100, "% accuracy, error:", err)
+	}
+}
+
+/**
+ * Feature Selection Check
+ * Checks that setting every bit passes every feature to the evaluator, and that
+ * clearing every bit passes none and scores 0
+ */
+func testFeatureSelection() {
+	fmt.Println("Checking feature selection masks.")
+
+	var X = [][]float32{{1, 5, 0}, {2, 3, 0}, {3, 8, 0}, {4, 1, 0}}
+	var y = []int{0, 0, 1, 1}
+
+	var received []int
+	var recording = func(features []int, X [][]float32, y []int) float32 {
+		received = features
+		return VarianceEvaluator(features, X, y)
+	}
+	var fitness = FeatureSelectionFitness(X, y, recording)
+
+	var all = DNAFromString("111")
+	if score := fitness(&all); len(received) == 3 && score > 0 {
+		fmt.Println("PASS: all bits set passed all", len(received), "features, scoring", score)
+	} else {
+		fmt.Println("FAIL: all bits set passed", len(received), "features, scoring", score)
+	}
+
+	var none = DNAFromString("000")
+	if score := fitness(&none); len(received) == 0 && score == 0 {
+		fmt.Println("PASS: all bits clear passed no features, scoring 0")
+	} else {
+		fmt.Println("FAIL: all bits clear passed", len(received), "features, scoring", score)
+	}
+}
+
+/**
+ * Aging Check
+ * Checks that an entity older than 1 / AgingPenalty is never selected for the
+ * mating pool, however fit it would otherwise be
+ */
+func testAging() {
+	fmt.Println("Checking old entities are selected out.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, AgingPenalty: 0.1, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	population.entities[0] = DNA{genes: []rune(target), Age: 11}
+	populationCalculateFitness(population, target)
+	populationNaturalSelection(population)
+
+	var selected int
+	for i := range population.matingPool {
+		if dnaExtractPhrase(&population.matingPool[i]) == target {
+			selected++
+		}
+	}
+
+	if len(population.matingPool) > 0 && selected == 0 {
+		fmt.Println("PASS: entity aged 11 was not selected into a mating pool of", len(population.matingPool))
+	} else {
+		fmt.Println("FAIL: entity aged 11 was selected", selected, "times into a mating pool of", len(population.matingPool))
+	}
+
+	// Copies of an entity are as old as it is, so can't escape the penalty
+	var snapshot = population.Snapshot()
+	if clone := population.entities[0].Clone(); clone.Age == 11 && snapshot.Entities[0].Age == 11 {
+		fmt.Println("PASS: clones and snapshots keep the entity's age of 11")
+	} else {
+		fmt.Println("FAIL: a clone was aged", clone.Age, "and a snapshot", snapshot.Entities[0].Age, "not 11")
+	}
+}
+
+/**
+ * Multi Start Check
+ * Checks that a single start and several parallel starts both return a solved
+ * population
+ */
+func testMultiStart() {
+	fmt.Println("Checking multiple starts return the best population.")
+
+	var cfg = Config{Target: "genetic", MaxPop: 100, MutationRate: 0.01, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, Seed: 42}
+
+	for _, n := range []int{1, 3} {
+		var population, err = MultiStart(context.Background(), n, cfg)
+
+		if err == nil && population != nil && populationGetBest(population) == cfg.Target {
+			fmt.Println("PASS:", n, "starts returned a solved population")
+		} else {
+			fmt.Println("FAIL:", n, "starts did not return a solved population, error:", err)
+		}
+	}
+}
+
+/**
+ * LaMarckian Check
+ * Checks that writing local search improvements back to the genes converges in
+ * fewer generations on average than pure Darwinian evolution
+ */
+func testLaMarckian() {
+	fmt.Println("Checking LaMarckian evolution converges faster than Darwinian.")
+
+	const trials = 10
+	var average = func(lamarckian bool) float32 {
+		var total int
+		for trial := 0; trial < trials; trial++ {
+			var population, err = NewPopulation(Config{Target: "genetic", MaxPop: 100, MutationRate: 0.01, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, LaMarckianMode: lamarckian, LocalSearchSteps: 5, Seed: 42 + int64(trial)})
+			if err == nil {
+				err = RunN(context.Background(), 1000, population)
+			}
+			if err != nil {
+				fmt.Println("Error:", err)
+				return float32(math.Inf(1))
+			}
+			total += population.generations
+		}
+		return float32(total) / trials
+	}
+
+	var darwinian, lamarckian = average(false), average(true)
+
+	if lamarckian < darwinian {
+		fmt.Println("PASS: LaMarckian converged in", lamarckian, "generations on average, Darwinian in", darwinian)
+	} else {
+		fmt.Println("FAIL: LaMarckian converged in", lamarckian, "generations on average, Darwinian in", darwinian)
+	}
+}
+
+/**
+ * Fitness Cache Check
+ * Checks that the elite entities, surviving each generation unchanged, are
+ * found in the fitness cache every generation after the first
+ */
+func testFitnessCache() {
+	fmt.Println("Checking the fitness cache is hit by surviving elites.")
+
+	var cache = &FitnessCache{}
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, EliteCount: 5, FitnessCache: cache, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var missed int
+	for generation := 0; generation < 10 && err == nil; generation++ {
+		var hits = cache.hits
+		err = evolve(population)
+		if cache.hits-hits < 5 {
+			missed++
+		}
+	}
+
+	if err == nil && missed == 0 {
+		fmt.Println("PASS: elites were cache hits every generation, hit rate", cache.CacheHitRate())
+	} else {
+		fmt.Println("FAIL: elites were not cache hits in", missed, "generations, error:", err)
+	}
+}
+
+/**
+ * Novelty Archive Check
+ * Checks that an archive over capacity keeps only its capacity, pruning the
+ * crowded behaviours and keeping the spread out ones
+ */
+func testNoveltyArchive() {
+	fmt.Println("Checking the novelty archive prunes crowded behaviours.")
+
+	// 100 behaviours spread over a grid, interleaved with 100 crowded together
+	var archive = NoveltyArchive{Capacity: 100}
+	for i := 0; i < 100; i++ {
+		archive.Add([]float64{float64(i%10) * 10, float64(i/10) * 10})
+		archive.Add([]float64{45 + float64(i%10)*0.01, 45 + float64(i/10)*0.01})
+	}
+
+	var crowded int
+	for _, behavior := range archive.Behaviors {
+		if behavior[0] > 44 && behavior[0] < 46 {
+			crowded++
+		}
+	}
+
+	if len(archive.Behaviors) == 100 && crowded <= 1 {
+		fmt.Println("PASS: archive kept 100 behaviours,", crowded, "of them crowded")
+	} else {
+		fmt.Println("FAIL: archive kept", len(archive.Behaviors), "behaviours,", crowded, "of them crowded")
+	}
+}
+
+/**
+ * Boltzmann Sharing Check
+ * Checks that a very large sigma shares fitness between every entity, that a
+ * tiny sigma shares none, and that equidistant entities keep their order
+ */
+func testBoltzmannSharing() {
+	fmt.Println("Checking Boltzmann fitness sharing.")
+
+	// Every pair of these entities is at distance 3
+	var shared = func(sigma float32) []float32 {
+		var population = Population{entities: []DNA{
+			{genes: []rune("aaa"), fitness: 0.9},
+			{genes: []rune("bbb"), fitness: 0.6},
+			{genes: []rune("ccc"), fitness: 0.3},
+		}}
+		ApplyBoltzmannFitnessSharing(&population, sigma)
+		return []float32{population.entities[0].fitness, population.entities[1].fitness, population.entities[2].fitness}
+	}
+
+	var crowded, alone = shared(1e6), shared(0.01)
+
+	if math.Abs(float64(crowded[0])-0.3) < 1e-4 && crowded[0] > crowded[1] && crowded[1] > crowded[2] {
+		fmt.Println("PASS: large sigma divided fitness between all entities, keeping their order:", crowded)
+	} else {
+		fmt.Println("FAIL: large sigma shared fitness as", crowded)
+	}
+
+	if alone[0] == 0.9 && alone[1] == 0.6 && alone[2] == 0.3 {
+		fmt.Println("PASS: tiny sigma shared no fitness")
+	} else {
+		fmt.Println("FAIL: tiny sigma shared fitness as", alone)
+	}
+}
+
+/**
+ * Hypermutation Check
+ * Checks that a population stuck without mutation on a local optimum escapes it
+ * once hypermutation is triggered
+ */
+func testHypermutation() {
+	fmt.Println("Checking hypermutation escapes a local optimum.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: 0, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	// Every entity the same near miss, which crossover alone can never change
+	var stuck = "I think, therefore I am!"
+	for i := range population.entities {
+		population.entities[i] = DNAFromString(stuck)
+	}
+	populationCalculateFitness(population, target)
+
+	var trigger = &HypermutationTrigger{StagnationWindow: 3, HighRate: 0.5, Duration: 5}
+	population.MutationAdaptor = trigger
+
+	// Stagnation is detected after the window, then the high rate applies from the next generation
+	err = RunN(context.Background(), trigger.StagnationWindow+1+trigger.Duration, population)
+
+	if escaped := populationGetBest(population) != stuck; err == nil && escaped && population.cfg.MutationRate == 0 {
+		fmt.Println("PASS: population escaped the local optimum and returned to its baseline rate")
+	} else {
+		fmt.Println("FAIL: population escaped:", escaped, "with mutation rate", population.cfg.MutationRate, "error:", err)
+	}
+}
+
+/**
+ * Reproduction Mode Check
+ * Checks that a population half of clonal entities breeds roughly half of its
+ * children by cloning and half by crossover
+ */
+func testReproductionMode() {
+	fmt.Println("Checking a heterogeneous population breeds by both crossover and cloning.")
+
+	var clonal, total int
+	for trial := 0; trial < 20; trial++ {
+		var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42 + int64(trial)})
+		if err != nil {
+			fmt.Println("FAIL: could not create population:", err)
+			return
+		}
+		for i := range population.entities {
+			population.entities[i].ReproductionMode = ReproductionMode(i % 2)
+		}
+
+		populationSelect(population)
+		if err = populationNextGeneration(population); err != nil {
+			fmt.Println("FAIL: could not breed population:", err)
+			return
+		}
+
+		// Without mode mutation, each child's mode is the mode it was bred by
+		for i := range population.entities {
+			if population.entities[i].ReproductionMode == ClonalMode {
+				clonal++
+			}
+			total++
+		}
+	}
+
+	if fraction := float32(clonal) / float32(total); fraction > 0.4 && fraction < 0.6 {
+		fmt.Println("PASS:", fraction*100, "% of children were cloned")
+	} else {
+		fmt.Println("FAIL:", fraction*100, "% of children were cloned")
+	}
+}
+
+/**
+ * Greedy Crossover Check
+ * Checks that a greedy crossover child is never less fit than either parent
+ */
+func testGreedyCrossover() {
+	fmt.Println("Checking greedy crossover never loses fitness.")
+
+	var rng = NewPRNG(42)
+
+	var worse int
+	for trial := 0; trial < 1000; trial++ {
+		var a, b = DNA{}, DNA{}
+		dnaCreate(rng, &a, len(target), LowercaseAlpha)
+		dnaCreate(rng, &b, len(target), LowercaseAlpha)
+		dnaAssessFitness(&a, target)
+		dnaAssessFitness(&b, target)
+
+		var child = dnaGreedyCrossover(rng, &a, &b, target)
+		dnaAssessFitness(&child, target)
+
+		if child.fitness < a.fitness || child.fitness < b.fitness {
+			worse++
+		}
+	}
+
+	if worse == 0 {
+		fmt.Println("PASS: every greedy crossover child was at least as fit as its parents")
+	} else {
+		fmt.Println("FAIL:", worse, "greedy crossover children were less fit than a parent")
+	}
+}
+
+/**
+ * Dynamic Migration Check
+ * Checks that two initially identical islands only migrate once their best
+ * entities have diverged beyond the threshold
+ */
+func testDynamicMigration() {
+	fmt.Println("Checking islands migrate once they have diverged.")
+
+	var first, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+	var secondCfg = first.cfg
+	secondCfg.Seed = 43
+	var second, _ = NewPopulation(secondCfg)
+	for i := range first.entities {
+		second.entities[i] = first.entities[i].Clone()
+	}
+	populationCalculateFitness(second, target)
+
+	var model = IslandModel{
+		Islands:                  []*Population{first, second},
+		Topology:                 RingTopology,
+		MigrationCount:           2,
+		DynamicMigrationInterval: true,
+		DivergenceThreshold:      5,
+	}
+	var identical = model.Divergence() == 0
+
+	// The last island to evolve records the divergence migration is decided on
+	var divergences []float64
+	second.cfg.OnGenerationEnd = func(*Population) {
+		divergences = append(divergences, model.Divergence())
+	}
+
+	for generation := 0; generation < 20 && model.migrations == 0 && err == nil; generation++ {
+		err = model.Evolve()
+	}
+
+	var last = len(divergences) - 1
+	if last < 0 {
+		fmt.Println("FAIL: islands did not evolve, error:", err)
+		return
+	}
+
+	var ok = err == nil && identical && model.migrations == 1 && divergences[last] > model.DivergenceThreshold
+	for _, divergence := range divergences[:last] {
+		ok = ok && divergence <= model.DivergenceThreshold
+	}
+
+	if ok {
+		fmt.Println("PASS: identical islands first migrated after diverging by", divergences[last], "at generation", model.generations)
+	} else {
+		fmt.Println("FAIL: islands identical:", identical, "migrated:", model.migrations, "divergences:", divergences, "error:", err)
+	}
+}
+
+/**
+ * Grid Search Check
+ * Checks that a grid search returns one result per parameter combination, each
+ * holding the parameters it was run with
+ */
+func testGridSearch() {
+	fmt.Println("Checking a grid search covers every parameter combination.")
+
+	var grid = ParameterGrid{
+		MaxPops:        []int{10, 20},
+		MutRates:       []float32{0.01, 0.05},
+		CrossoverRates: []float32{1.0},
+		Selectors:      []Selector{FitnessProportionateSelector{}, &TournamentSelector{Size: 3}},
+	}
+	var results = GridSearch(context.Background(), grid, nil, []int64{1, 2}, 3)
+
+	var ok = len(results) == len(grid.MaxPops)*len(grid.MutRates)*len(grid.CrossoverRates)*len(grid.Selectors)
+	var i int
+	for _, maxPop := range grid.MaxPops {
+		for _, mutRate := range grid.MutRates {
+			for _, crossoverRate := range grid.CrossoverRates {
+				for _, selector := range grid.Selectors {
+					ok = ok && i < len(results) && results[i].MaxPop == maxPop && results[i].MutationRate == mutRate &&
+						results[i].CrossoverRate == crossoverRate && results[i].Selector == selector
+					i++
+				}
+			}
+		}
+	}
+
+	if ok {
+		fmt.Println("PASS: grid search returned all", len(results), "combinations")
+	} else {
+		fmt.Println("FAIL: grid search returned", len(results), "results not matching the grid")
+	}
+}
+
+/**
+ * Tournament Replacement Check
+ * Checks that a generation of tournament replacement never replaces an entity
+ * with a less fit one, and that the entities surviving it age
+ */
+func testTournamentReplacement() {
+	fmt.Println("Checking tournament replacement only replaces with fitter children.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, GenerationMode: TournamentReplacementMode, TournamentSize: 3, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var before = make([]float32, len(population.entities))
+	var ids = make([]int64, len(population.entities))
+	for i := range population.entities {
+		before[i] = population.entities[i].fitness
+		ids[i] = population.entities[i].ID
+	}
+
+	err = populationNextGeneration(population)
+
+	var worse, replaced, survived, aged int
+	for i := range population.entities {
+		if population.entities[i].fitness < before[i] {
+			worse++
+		}
+		if population.entities[i].fitness != before[i] {
+			replaced++
+		}
+		if population.entities[i].ID == ids[i] {
+			survived++
+			if population.entities[i].Age == 1 {
+				aged++
+			}
+		}
+	}
+
+	if err == nil && worse == 0 {
+		fmt.Println("PASS: no entity was replaced by a less fit child,", replaced, "were replaced by fitter")
+	} else {
+		fmt.Println("FAIL:", worse, "entities were replaced by less fit children, error:", err)
+	}
+
+	if survived > 0 && aged == survived {
+		fmt.Println("PASS: all", survived, "surviving entities aged a generation")
+	} else {
+		fmt.Println("FAIL:", aged, "of", survived, "surviving entities aged a generation")
+	}
+}
+
+/**
+ * Polynomial Mutation Check
+ * Checks that a large distribution index makes small perturbations, and that a
+ * small one perturbs across the full range
+ */
+func testPolynomialMutation() {
+	fmt.Println("Checking polynomial mutation perturbation sizes.")
+
+	var rng = NewPRNG(42)
+	var bounds = [][2]float64{{-1, 1}}
+	var perturbations = func(eta float64) []float64 {
+		var perturbed = make([]float64, 1000)
+		for i := range perturbed {
+			var entity = FloatDNA{genes: []float64{0}}
+			floatDNAPolynomialMutate(rng, &entity, bounds, eta, 1.0)
+			perturbed[i] = entity.genes[0]
+		}
+		sort.Float64s(perturbed)
+		return perturbed
+	}
+
+	// Half of the perturbations lie either side of the median size
+	var small = perturbations(1000)
+	var sizes = make([]float64, len(small))
+	for i, gene := range small {
+		sizes[i] = math.Abs(gene)
+	}
+	sort.Float64s(sizes)
+
+	if median := sizes[len(sizes)/2] / 2; median < 0.001 {
+		fmt.Println("PASS: at eta 1000 the median perturbation is", median*100, "% of the range")
+	} else {
+		fmt.Println("FAIL: at eta 1000 the median perturbation is", median*100, "% of the range")
+	}
+
+	var large = perturbations(0.01)
+	if large[0] < -0.9 && large[len(large)-1] > 0.9 {
+		fmt.Println("PASS: at eta 0.01 perturbations span the range, from", large[0], "to", large[len(large)-1])
+	} else {
+		fmt.Println("FAIL: at eta 0.01 perturbations span only", large[0], "to", large[len(large)-1])
+	}
+}
+
+/**
+ * Compact GA Check
+ * Checks that the compact GA finds a short phrase within 10000 steps
+ */
+func testCompactGA() {
+	fmt.Println("Checking the compact GA converges on a short phrase.")
+
+	var target = "Hello!"
+	var cga = NewCompactGA(NewPRNG(42), len(target), target)
+
+	for step := 1; step <= 10000; step++ {
+		var best, converged = cga.Step()
+		if dnaExtractPhrase(&best) == target {
+			fmt.Println("PASS: compact GA found", target, "after", step, "steps")
+			return
+		}
+		if converged {
+			fmt.Println("FAIL: compact GA converged on", dnaExtractPhrase(&best), "after", step, "steps")
+			return
+		}
+	}
+
+	fmt.Println("FAIL: compact GA did not find", target, "within 10000 steps")
+}
+
+/**
+ * (μ + λ) Check
+ * Checks that the combined pool is cut back to μ entities, none of them less
+ * fit than the weakest parent
+ */
+func testMuPlusLambda() {
+	fmt.Println("Checking (mu + lambda) keeps the fittest mu of parents and children.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 250, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var parents = PopulationTopK(population, len(population.entities))
+	var weakest = parents[len(parents)-1].fitness
+
+	populationSelect(population)
+	err = MuPlusLambdaGenerate(population, 50)
+
+	var below int
+	for i := range population.entities {
+		if population.entities[i].fitness < weakest {
+			below++
+		}
+	}
+
+	if err == nil && len(population.entities) == 250 && below == 0 && population.bestFitness >= parents[0].fitness {
+		fmt.Println("PASS: 250 entities survive, none less fit than the weakest parent")
+	} else {
+		fmt.Println("FAIL:", len(population.entities), "entities survive,", below, "less fit than the weakest parent, error:", err)
+	}
+}
+
+/**
+ * (μ, λ) Check
+ * Checks that the population is replaced by exactly μ children, none of them
+ * an unchanged parent
+ */
+func testMuCommaLambda() {
+	fmt.Println("Checking (mu, lambda) replaces every parent with children.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: 0.05, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var parents = make(map[string]bool)
+	for i := range population.entities {
+		parents[dnaExtractPhrase(&population.entities[i])] = true
+	}
+
+	err = MuCommaLambdaGenerate(population, 50, 200)
+
+	var survivors int
+	for i := range population.entities {
+		if parents[dnaExtractPhrase(&population.entities[i])] {
+			survivors++
+		}
+	}
+
+	if err == nil && len(population.entities) == 50 && survivors == 0 {
+		fmt.Println("PASS: 50 children replaced the population, no parent survived")
+	} else {
+		fmt.Println("FAIL:", len(population.entities), "entities,", survivors, "identical to a parent, error:", err)
+	}
+}
+
+/**
+ * Population Reset Check
+ * Checks that a reset population starts again from generation 0, at full size,
+ * without sharing any genes with the population before the reset
+ */
+func testPopulationReset() {
+	fmt.Println("Checking a reset population starts again with fresh DNA.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42, AuditMutations: true})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var adaptor = &GenerationImprovementAdaptor{Window: 2, Threshold: 1.0, BoostFactor: 2.0, DecayFactor: 0.5, Rate: mutrate}
+	population.MutationAdaptor = adaptor
+	for i := 0; i < 5; i++ {
+		evolve(population)
+	}
+
+	var before = make(map[*rune]bool)
+	for i := range population.entities {
+		before[&population.entities[i].genes[0]] = true
+	}
+
+	population.Reset(43)
+
+	var shared int
+	for i := range population.entities {
+		if before[&population.entities[i].genes[0]] {
+			shared++
+		}
+	}
+
+	if population.generations == 0 && len(population.entities) == population.cfg.MaxPop && shared == 0 {
+		fmt.Println("PASS: reset population is at generation 0 with", len(population.entities), "new entities")
+	} else {
+		fmt.Println("FAIL: reset population is at generation", population.generations, "with", len(population.entities), "entities,", shared, "sharing genes")
+	}
+
+	if population.cfg.MutationRate == mutrate && adaptor.Rate == mutrate && len(adaptor.History) == 0 && population.Timer.Generations == 0 && len(population.Audit.Events) == 0 {
+		fmt.Println("PASS: reset population mutates at", population.cfg.MutationRate, "with an empty timer, audit and adaptor history")
+	} else {
+		fmt.Println("FAIL: reset population mutates at", population.cfg.MutationRate, "after", population.Timer.Generations, "timed generations and", len(population.Audit.Events), "audited mutations")
+	}
+}
+
+/**
+ * Median Fitness Check
+ * Checks that a single outlying entity skews the average but not the median
+ */
+func testMedianFitness() {
+	fmt.Println("Checking the median fitness is robust to outliers.")
+
+	var population = &Population{entities: make([]DNA, 250)}
+	population.entities[0].fitness = 1.0
+
+	var stats = PopulationGenerationStats(population)
+	if math.Abs(float64(stats.AverageFitness)-0.004) < 0.0001 && stats.MedianFitness == 0 && stats.BestFitness == 1.0 {
+		fmt.Println("PASS: one outlier moves the mean to", stats.AverageFitness, "but the median stays at", stats.MedianFitness)
+	} else {
+		fmt.Println("FAIL: mean", stats.AverageFitness, "median", stats.MedianFitness, "best", stats.BestFitness)
+	}
+
+	population.entities = []DNA{{fitness: 0.1}, {fitness: 0.4}, {fitness: 0.2}, {fitness: 0.3}}
+	if median := populationMedianFitness(population); math.Abs(float64(median)-0.25) < 0.0001 {
+		fmt.Println("PASS: the median of an even count averages the middle two:", median)
+	} else {
+		fmt.Println("FAIL: the median of 0.1, 0.2, 0.3 and 0.4 was", median)
+	}
+}
+
+/**
+ * DNA Diff Check
+ * Checks the positions reported as differing between identical, completely
+ * different and partially matching dna
+ */
+func testDNADiff() {
+	fmt.Println("Checking DNA diffs report the differing positions.")
+
+	var a = DNAFromString("genetic")
+	var same = DNAFromString("genetic")
+	var different = DNAFromString("GENETIC")
+	var partial = DNAFromString("generic")
+
+	if diff, err := DNADiff(&a, &same); err == nil && len(diff) == 0 {
+		fmt.Println("PASS: identical DNA have no differences")
+	} else {
+		fmt.Println("FAIL: identical DNA differ at", diff, "error:", err)
+	}
+
+	if diff, err := DNADiff(&a, &different); err == nil && len(diff) == len(a.genes) {
+		fmt.Println("PASS: completely different DNA differ at every position")
+	} else {
+		fmt.Println("FAIL: completely different DNA differ at", diff, "error:", err)
+	}
+
+	if diff, err := DNADiff(&a, &partial); err == nil && reflect.DeepEqual(diff, []int{4}) {
+		fmt.Println("PASS: partially matching DNA differ at", DNADiffString(&a, &partial))
+	} else {
+		fmt.Println("FAIL: partially matching DNA differ at", diff, "error:", err)
+	}
+
+	var short = DNAFromString("gene")
+	if _, err := DNADiff(&a, &short); err != nil {
+		fmt.Println("PASS: DNA of different lengths can't be diffed:", err)
+	} else {
+		fmt.Println("FAIL: DNA of different lengths were diffed")
+	}
+}
+
+/**
+ * Extract Phrase Range Check
+ * Checks reading ranges and single genes at the boundaries of the dna, outside
+ * of them, and an empty range
+ */
+func testExtractPhraseRange() {
+	fmt.Println("Checking partial reads of the genes.")
+
+	var dna = DNAFromString("genetic")
+
+	var ranges = []struct {
+		start, end int
+		want       string
+		valid      bool
+	}{
+		{0, 7, "genetic", true},
+		{0, 1, "g", true},
+		{6, 7, "c", true},
+		{3, 3, "", true},
+		{-1, 3, "", false},
+		{5, 8, "", false},
+		{4, 2, "", false},
+	}
+
+	for _, r := range ranges {
+		var phrase, err = ExtractPhraseRange(&dna, r.start, r.end)
+		if phrase == r.want && (err == nil) == r.valid {
+			fmt.Println("PASS: range", r.start, "to", r.end, "read", phrase, "with error", err)
+		} else {
+			fmt.Println("FAIL: range", r.start, "to", r.end, "read", phrase, "with error", err)
+		}
+	}
+
+	var first, errFirst = ExtractGeneAt(&dna, 0)
+	var last, errLast = ExtractGeneAt(&dna, 6)
+	var _, errPast = ExtractGeneAt(&dna, 7)
+	var _, errNegative = ExtractGeneAt(&dna, -1)
+
+	if first == 'g' && last == 'c' && errFirst == nil && errLast == nil && errPast != nil && errNegative != nil {
+		fmt.Println("PASS: single genes read at the boundaries and not outside of them")
+	} else {
+		fmt.Println("FAIL: read", first, last, "with errors", errFirst, errLast, errPast, errNegative)
+	}
+}
+
+/**
+ * Scored DNA Check
+ * Checks that each assessment is kept, and that an entity improving with every
+ * assessment has a positive growth rate
+ */
+func testScoredDNA() {
+	fmt.Println("Checking scored DNA keeps its fitness history.")
+
+	var scored = ScoredDNA{DNA: DNAFromString("xxxxxxx")}
+	for _, phrase := range []string{"gxxxxxx", "genxxxx", "genetic"} {
+		scored.genes = []rune(phrase)
+		scored.AssessFitness("genetic")
+	}
+
+	if len(scored.FitnessHistory) == 3 && scored.fitness == 1.0 {
+		fmt.Println("PASS: 3 assessments kept", scored.FitnessHistory)
+	} else {
+		fmt.Println("FAIL: 3 assessments kept", scored.FitnessHistory, "latest fitness", scored.fitness)
+	}
+
+	if rate := scored.FitnessGrowthRate(); rate > 0 {
+		fmt.Println("PASS: an improving entity grows at", rate, "per assessment")
+	} else {
+		fmt.Println("FAIL: an improving entity grows at", rate, "per assessment")
+	}
+}
+
+/**
+ * Partially Deterministic Initialization Check
+ * Checks that generation 0 starts with the hints, and that the rest of it is
+ * random
+ */
+func testPartiallyDeterministicInitialization() {
+	fmt.Println("Checking generation 0 can be seeded with hints.")
+
+	var hints = []string{"I think, therefore I ab.", "I think, therefore I zm."}
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42,
+		Initialization: PartiallyDeterministic{Hints: hints, HintFraction: 0.1}})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var hinted, hintsLater int
+	for i := range population.entities {
+		var phrase = dnaExtractPhrase(&population.entities[i])
+		if i < 10 && phrase == hints[i%len(hints)] {
+			hinted++
+		}
+		if i >= 10 && (phrase == hints[0] || phrase == hints[1]) {
+			hintsLater++
+		}
+	}
+	var random = PopulationUniqueCount(population) - len(hints)
+
+	if len(population.entities) == 100 && hinted == 10 && hintsLater == 0 && random == 90 {
+		fmt.Println("PASS: the first 10 entities were hints, the other 90 random")
+	} else {
+		fmt.Println("FAIL:", hinted, "of the first 10 entities were hints,", hintsLater, "later ones matched a hint and", random, "were distinct")
+	}
+}
+
+/**
+ * Weighted Sum Fitness Check
+ * Checks that a single fully weighted objective is unchanged, and that equal
+ * weights average the objectives
+ */
+func testWeightedSumFitness() {
+	fmt.Println("Checking weighted sum fitness scalarizes objectives.")
+
+	var dna = DNAFromString("GATTACA")
+	var gc = GCContentFitness(0.5)
+	var compression = CompressionFitness(func(b []byte) int { return len(b) / 2 })
+
+	var single, err = WeightedSumFitness([]FitnessFunc{gc}, []float32{1.0})
+	if err == nil && single(&dna) == gc(&dna) {
+		fmt.Println("PASS: a single objective with weight 1.0 is unchanged:", single(&dna))
+	} else {
+		fmt.Println("FAIL: a single objective with weight 1.0 gave error", err)
+	}
+
+	var mean = (gc(&dna) + compression(&dna)) / 2
+	var equal FitnessFunc
+	equal, err = WeightedSumFitness([]FitnessFunc{gc, compression}, NormalizeWeights([]float32{1, 1}))
+
+	if err == nil && math.Abs(float64(equal(&dna)-mean)) < 1e-6 {
+		fmt.Println("PASS: equal weights give the mean of the objectives:", mean)
+	} else {
+		fmt.Println("FAIL: equal weights did not give the mean of the objectives, error:", err)
+	}
+
+	if _, err = WeightedSumFitness([]FitnessFunc{gc, compression}, []float32{0.5, 0.6}); err != nil {
+		fmt.Println("PASS: weights not summing to 1.0 are rejected:", err)
+	} else {
+		fmt.Println("FAIL: weights summing to 1.1 were accepted")
+	}
+}
+
+/**
+ * Format Progress Bar Check
+ * Checks the width of the progress bar, and that it is full at a fitness of 1.0
+ * and empty at 0.0
+ */
+func testFormatProgressBar() {
+	fmt.Println("Checking progress bars are drawn to width.")
+
+	var width = 20
+	var bars = []struct {
+		fitness float32
+		want    string
+	}{
+		{1.0, "[" + strings.Repeat("=", width) + "]"},
+		{0.0, "[" + strings.Repeat(" ", width) + "]"},
+		{0.5, "[=========>" + strings.Repeat(" ", 10) + "]"},
+	}
+
+	for _, b := range bars {
+		var stats = GenerationStats{Generation: 42, BestFitness: b.fitness, BestPhrase: "I think, the"}
+		var bar = FormatProgressBar(stats, width)
+
+		// "Gen 42 " before the bar, then " 100% Best: " and the quoted phrase after it
+		var overhead = len("Gen 42 ") + 2 + len(fmt.Sprintf(" %d%% Best: ", int(b.fitness*100))) + len(`"I think, the"`)
+		if strings.Contains(bar, b.want) && len(bar) == width+overhead {
+			fmt.Println("PASS:", bar)
+		} else {
+			fmt.Println("FAIL: fitness", b.fitness, "drew", bar)
+		}
+	}
+}
+
+/**
+ * Event Log Check
+ * Checks that logging 5 generations writes 5 lines of valid JSON
+ */
+func testEventLog() {
+	fmt.Println("Checking the event log writes a JSON line per generation.")
+
+	var file, err = os.CreateTemp("", "event-log-*.jsonl")
+	if err != nil {
+		fmt.Println("FAIL: could not create a temporary file:", err)
+		return
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	var log *EventLog
+	if log, err = NewEventLog(file.Name()); err != nil {
+		fmt.Println("FAIL: could not create event log:", err)
+		return
+	}
+
+	var population *Population
+	if population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42, EventLog: log}); err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	err = RunN(context.Background(), 5, population)
+	if closeErr := log.Close(); err == nil {
+		err = closeErr
+	}
+
+	var contents []byte
+	if err == nil {
+		contents, err = os.ReadFile(file.Name())
+	}
+
+	var lines = strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+	var valid int
+	for _, line := range lines {
+		if json.Valid([]byte(line)) {
+			valid++
+		}
+	}
+
+	if err == nil && len(lines) == 5 && valid == 5 {
+		fmt.Println("PASS: 5 generations logged as 5 JSON lines, the first:", lines[0])
+	} else {
+		fmt.Println("FAIL:", valid, "of", len(lines), "lines were valid JSON, error:", err)
+	}
+}
+
+/**
+ * Population DOT Check
+ * Checks that the evolution tree is a well formed digraph, in which the
+ * entities of generation 0 have no parents
+ */
+func testPopulationDot() {
+	fmt.Println("Checking the evolution tree is drawn as a DOT digraph.")
+
+	var recorder = &PopulationRecorder{}
+	var population, err = NewPopulation(Config{Target: "genetic", MaxPop: 10, MutationRate: mutrate, CrossoverRate: 1.0, Alphabet: LowercaseAlpha, Seed: 42, OnGenerationEnd: recorder.Record})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var roots = make(map[string]bool)
+	for i := range population.entities {
+		roots[fmt.Sprintf("n%d", population.entities[i].ID)] = true
+	}
+
+	err = RunN(context.Background(), 3, population)
+	var dot = PopulationDot(population, recorder)
+
+	var lines = strings.Split(strings.TrimSpace(dot), "\n")
+	var node = regexp.MustCompile(`^\tn\d+( \[label=".*"\])?;$`)
+	var edge = regexp.MustCompile(`^\tn\d+ -> (n\d+);$`)
+
+	var malformed, edges, rootEdges int
+	for _, line := range lines[1 : len(lines)-1] {
+		if match := edge.FindStringSubmatch(line); match != nil {
+			edges++
+			if roots[match[1]] {
+				rootEdges++
+			}
+		} else if !node.MatchString(line) {
+			malformed++
+		}
+	}
+
+	if err == nil && lines[0] == "digraph evolution {" && lines[len(lines)-1] == "}" && malformed == 0 && edges > 0 && rootEdges == 0 {
+		fmt.Println("PASS: digraph of", len(lines)-2-edges, "entities and", edges, "edges, none into generation 0")
+	} else {
+		fmt.Println("FAIL:", malformed, "malformed lines,", edges, "edges,", rootEdges, "into generation 0, error:", err)
+	}
+}
+
+/**
+ * Async Evaluator Check
+ * Checks that a slow fitness function is evaluated concurrently by the workers,
+ * that an evaluator never started or already stopped still evaluates, and that
+ * its results get the same constraint, penalties and cache as the population's
+ * own assessment
+ */
+func testAsyncEvaluator() {
+	fmt.Println("Checking the async evaluator runs slow evaluations concurrently.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var evaluator = NewAsyncEvaluator(10, func(dna *DNA) float32 {
+		time.Sleep(10 * time.Millisecond)
+		return GCContent(dna)
+	})
+	evaluator.Start()
+	defer evaluator.Stop()
+
+	var start = time.Now()
+	populationCalculateFitnessAsync(population, evaluator, target)
+	var elapsed = time.Since(start)
+
+	// Sequentially, 100 entities would take a second
+	if elapsed < 500*time.Millisecond {
+		fmt.Println("PASS: 100 entities taking 10ms each were evaluated by 10 workers in", elapsed)
+	} else {
+		fmt.Println("FAIL: 100 entities taking 10ms each were evaluated by 10 workers in", elapsed)
+	}
+
+	// Neither an unstarted nor a stopped evaluator may hang or panic
+	var lifecycle = testRecover(func() bool {
+		var idle = NewAsyncEvaluator(2, GCContent)
+		var dna = DNAFromString("GGCC")
+		for _, stage := range []func(){func() {}, idle.Stop, idle.Start, idle.Stop} {
+			stage()
+			select {
+			case fitness := <-idle.Evaluate(&dna):
+				if fitness != 1.0 {
+					return false
+				}
+			case <-time.After(time.Second):
+				return false
+			}
+		}
+		idle.Stop()
+		return true
+	})
+
+	if lifecycle {
+		fmt.Println("PASS: the evaluator evaluated before starting and after stopping")
+	} else {
+		fmt.Println("FAIL: the evaluator hung or panicked before starting or after stopping")
+	}
+
+	// Evaluated either way, the constraint, penalty and aging penalty apply
+	var cfg = Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42,
+		ConstraintFn: func(dna *DNA) bool { return dna.genes[0] != 'H' },
+		PenaltyFn:    func(dna *DNA) float32 { return float32(dna.genes[1]%2) * 0.1 }, PenaltyWeight: 1.0,
+		AgingPenalty: 0.1}
+	var synchronous, syncErr = NewPopulation(cfg)
+	cfg.Evaluator, cfg.FitnessCache = NewAsyncEvaluator(4, nil), &FitnessCache{}
+	var asynchronous, asyncErr = NewPopulation(cfg)
+	defer cfg.Evaluator.Stop()
+
+	var matching = syncErr == nil && asyncErr == nil
+	for generation := 0; matching && generation < 5; generation++ {
+		for i := range synchronous.entities {
+			matching = matching && synchronous.entities[i].fitness == asynchronous.entities[i].fitness
+		}
+		matching = matching && evolve(synchronous) == nil && evolve(asynchronous) == nil
+	}
+
+	if matching && cfg.FitnessCache.CacheHitRate() > 0 {
+		fmt.Println("PASS: asynchronous fitness matched the population's own for 5 generations, with", cfg.FitnessCache.CacheHitRate(), "of evaluations cached")
+	} else {
+		fmt.Println("FAIL: asynchronous fitness differed from the population's own, errors:", syncErr, asyncErr)
+	}
+}
+
+/**
+ * Filter Population Check
+ * Checks filtering with predicates matching no entities, every entity, and
+ * those above a fitness threshold
+ */
+func testFilterPopulation() {
+	fmt.Println("Checking populations can be filtered by predicate.")
+
+	var population = &Population{entities: []DNA{{fitness: 0.1}, {fitness: 0.5}, {fitness: 0.9}, {fitness: 0.7}}}
+
+	if none := FilterPopulation(population, AboveFitness(1.0)); none != nil && len(none) == 0 {
+		fmt.Println("PASS: no entity is above fitness 1.0")
+	} else {
+		fmt.Println("FAIL: entities above fitness 1.0:", none)
+	}
+
+	if all := FilterPopulation(population, BelowFitness(1.0)); len(all) == len(population.entities) {
+		fmt.Println("PASS: every entity is below fitness 1.0")
+	} else {
+		fmt.Println("FAIL:", len(all), "entities are below fitness 1.0")
+	}
+
+	var above = FilterPopulation(population, AboveFitness(0.6))
+	if len(above) == 2 && above[0].fitness == 0.9 && above[1].fitness == 0.7 && CountIf(population, AboveFitness(0.6)) == 2 {
+		fmt.Println("PASS: the 2 entities above fitness 0.6 were found")
+	} else {
+		fmt.Println("FAIL: entities above fitness 0.6:", above)
+	}
+}
+
+/**
+ * Map Population Check
+ * Checks that mapping transforms each entity into a new population, leaving the
+ * original unchanged
+ */
+func testMapPopulation() {
+	fmt.Println("Checking populations can be mapped without modifying them.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 20, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+	evolve(population)
+
+	var before = PopulationAllPhrases(population, 0)
+	var mapped = MapPopulation(population, func(dna DNA) DNA {
+		return DNA{genes: []rune(strings.ToUpper(dnaExtractPhrase(&dna))), fitness: dna.fitness}
+	})
+
+	var transformed int
+	for i := range mapped.entities {
+		if dnaExtractPhrase(&mapped.entities[i]) == strings.ToUpper(dnaExtractPhrase(&population.entities[i])) {
+			transformed++
+		}
+	}
+
+	if PopulationAllPhrases(population, 0) == before && mapped.generations == population.generations && transformed == len(population.entities) {
+		fmt.Println("PASS: all", transformed, "entities were mapped at generation", mapped.generations, "leaving the original unchanged")
+	} else {
+		fmt.Println("FAIL:", transformed, "entities were mapped at generation", mapped.generations, "of", population.generations)
+	}
+
+	// The original recycles its genes as it breeds, which must not change the copies
+	var unchanged = MapPopulation(population, func(dna DNA) DNA { return dna })
+	var filtered = &Population{entities: FilterPopulation(population, BelowFitness(2.0))}
+	before = PopulationAllPhrases(population, 0)
+	for i := 0; i < 5; i++ {
+		evolve(population)
+	}
+
+	if PopulationAllPhrases(unchanged, 0) == before && PopulationAllPhrases(filtered, 0) == before {
+		fmt.Println("PASS: mapped and filtered entities kept their genes while the original bred")
+	} else {
+		fmt.Println("FAIL: mapped or filtered entities changed while the original bred")
+	}
+}
+
+/**
+ * Consensus Sequence Check
+ * Checks that the consensus holds the most common rune at each position
+ */
+func testConsensusSequence() {
+	fmt.Println("Checking the consensus sequence of a population.")
+
+	var population = &Population{cfg: Config{Target: "ab"}, entities: []DNA{
+		{genes: []rune("ab")},
+		{genes: []rune("ab")},
+		{genes: []rune("ac")},
+	}}
+
+	var consensus = ConsensusSequence(population)
+	if dnaExtractPhrase(&consensus) == "ab" && consensus.fitness == 1.0 {
+		fmt.Println("PASS: consensus of ab, ab and ac is", dnaExtractPhrase(&consensus), "with fitness", consensus.fitness)
+	} else {
+		fmt.Println("FAIL: consensus of ab, ab and ac is", dnaExtractPhrase(&consensus), "with fitness", consensus.fitness)
+	}
+}
+
+/**
+ * Zip Populations Check
+ * Checks that zipping pairs the entities at each index, and rejects populations
+ * of different sizes
+ */
+func testZipPopulations() {
+	fmt.Println("Checking populations are zipped entity by entity.")
+
+	var a = &Population{entities: []DNA{{genes: []rune("aaaa")}, {genes: []rune("abcd")}, {genes: []rune("wxyz")}}}
+	var b = &Population{entities: []DNA{{genes: []rune("aaaa")}, {genes: []rune("abzz")}, {genes: []rune("WXYZ")}}}
+
+	var rng = NewPRNG(42)
+	var voting = func(a, b DNA) DNA {
+		return VotingCrossover(rng, a, b)
+	}
+	var zipped, err = ZipPopulations(a, b, voting)
+
+	// Each gene of a child must come from one of the pair at its index
+	var paired int
+	for i := 0; err == nil && i < len(zipped.entities); i++ {
+		var mismatched = 0
+		for j, gene := range zipped.entities[i].genes {
+			if gene != a.entities[i].genes[j] && gene != b.entities[i].genes[j] {
+				mismatched++
+			}
+		}
+		if mismatched == 0 {
+			paired++
+		}
+	}
+
+	if err == nil && len(zipped.entities) == 3 && paired == 3 && strings.HasPrefix(dnaExtractPhrase(&zipped.entities[1]), "ab") {
+		fmt.Println("PASS: 3 entities were zipped, each from the pair at its index")
+	} else {
+		fmt.Println("FAIL:", paired, "entities were zipped from their pair, error:", err)
+	}
+
+	if _, err = ZipPopulations(a, &Population{entities: b.entities[:2]}, voting); err != nil {
+		fmt.Println("PASS: populations of different sizes can't be zipped:", err)
+	} else {
+		fmt.Println("FAIL: populations of different sizes were zipped")
+	}
+}
+
+/**
+ * Constrained DNA Creation Check
+ * Checks that permutation DNA holds no duplicate runes, and that an unsatisfiable
+ * constraint gives up
+ */
+func testConstrainedDNACreate() {
+	fmt.Println("Checking DNA is created satisfying its constraints.")
+
+	var rng = NewPRNG(42)
+	var unique = func(genes []rune) bool {
+		var seen = make(map[rune]bool, len(genes))
+		for _, gene := range genes {
+			if seen[gene] {
+				return false
+			}
+			seen[gene] = true
+		}
+		return true
+	}
+
+	var permutation = PermutationDNACreate(rng, 26, LowercaseAlpha)
+	if len(permutation.genes) == 26 && unique(permutation.genes) {
+		fmt.Println("PASS: permutation DNA has no duplicate runes:", dnaExtractPhrase(&permutation))
+	} else {
+		fmt.Println("FAIL: permutation DNA has duplicate runes:", dnaExtractPhrase(&permutation))
+	}
+
+	var constrained, err = ConstrainedDNACreate(rng, 4, LowercaseAlpha, unique, 100)
+	if err == nil && unique(constrained.genes) {
+		fmt.Println("PASS: constrained DNA has no duplicate runes:", dnaExtractPhrase(&constrained))
+	} else {
+		fmt.Println("FAIL: constrained DNA", dnaExtractPhrase(&constrained), "error:", err)
+	}
+
+	if _, err = ConstrainedDNACreate(rng, 27, LowercaseAlpha, unique, 10); err != nil {
+		fmt.Println("PASS: an unsatisfiable constraint gave up:", err)
+	} else {
+		fmt.Println("FAIL: 27 unique lowercase runes were created")
+	}
+}
+
+/**
+ * Coevolutionary Fitness Check
+ * Checks that entities are only rewarded for being fitter than average, and
+ * that evaluated concurrently, the mean is still worked out only once
+ */
+func testCoevolutionaryFitness() {
+	fmt.Println("Checking coevolutionary fitness rewards above average entities.")
+
+	var raw = func(dna *DNA) float32 {
+		dnaAssessFitness(dna, "genetic")
+		return dna.fitness
+	}
+
+	var population = &Population{entities: []DNA{{genes: []rune("genxxxx")}, {genes: []rune("genxxxx")}, {genes: []rune("genxxxx")}}}
+	var fitness = CoevolutionaryFitnessWrapper(population, raw)
+
+	var nonzero int
+	for i := range population.entities {
+		if fitness(&population.entities[i]) != 0 {
+			nonzero++
+		}
+	}
+	if nonzero == 0 {
+		fmt.Println("PASS: equally fit entities all have a coevolutionary fitness of 0")
+	} else {
+		fmt.Println("FAIL:", nonzero, "equally fit entities have a coevolutionary fitness")
+	}
+
+	population = &Population{entities: []DNA{{genes: []rune("genetic")}, {genes: []rune("genxxxx")}, {genes: []rune("xxxxxxx")}}}
+	fitness = CoevolutionaryFitnessWrapper(population, raw)
+
+	var dominant = fitness(&population.entities[0])
+	var others = fitness(&population.entities[1]) + fitness(&population.entities[2])
+	if dominant > 0 && others == 0 {
+		fmt.Println("PASS: the dominant entity has a coevolutionary fitness of", dominant, "the others 0")
+	} else {
+		fmt.Println("FAIL: the dominant entity has a coevolutionary fitness of", dominant, "the others", others)
+	}
+
+	var calls int64
+	var counted = func(dna *DNA) float32 {
+		atomic.AddInt64(&calls, 1)
+		return GCContent(dna)
+	}
+	fitness = CoevolutionaryFitnessWrapper(population, counted)
+
+	var wg sync.WaitGroup
+	var scores = make([]float32, 20)
+	for i := range scores {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var dna = DNAFromString("GCGCGCG")
+			scores[i] = fitness(&dna)
+		}(i)
+	}
+	wg.Wait()
+
+	var differing int
+	for _, score := range scores {
+		if score != scores[0] {
+			differing++
+		}
+	}
+
+	// The mean takes one call per entity, then each evaluation one more
+	if calls == int64(len(population.entities)+len(scores)) && differing == 0 {
+		fmt.Println("PASS: 20 concurrent evaluations took", calls, "calls and all scored", scores[0])
+	} else {
+		fmt.Println("FAIL: 20 concurrent evaluations took", calls, "calls, with", differing, "scores differing")
+	}
+}
+
+/**
+ * Detect Fixed Alleles Check
+ * Checks that every position is fixed in a population of identical entities,
+ * and none in a maximally diverse one
+ */
+func testDetectFixedAlleles() {
+	fmt.Println("Checking fixed alleles are detected.")
+
+	var identical = &Population{entities: []DNA{{genes: []rune("abcd")}, {genes: []rune("abcd")}, {genes: []rune("abcd")}}}
+	if fixed := DetectFixedAlleles(identical); reflect.DeepEqual(fixed, []int{0, 1, 2, 3}) && PopulationGenerationStats(identical).FixedAlleles == 4 {
+		fmt.Println("PASS: every allele of identical entities is fixed")
+	} else {
+		fmt.Println("FAIL: identical entities have alleles fixed at", fixed)
+	}
+
+	var diverse = &Population{entities: []DNA{{genes: []rune("abcd")}, {genes: []rune("bcda")}, {genes: []rune("cdab")}}}
+	if fixed := DetectFixedAlleles(diverse); fixed != nil && len(fixed) == 0 {
+		fmt.Println("PASS: no allele of diverse entities is fixed")
+	} else {
+		fmt.Println("FAIL: diverse entities have alleles fixed at", fixed)
+	}
+}
+
+/**
+ * Allele Frequencies Check
+ * Checks that the allele frequencies of each position sum to 1.0, and that a
+ * position held by a single allele has a frequency of 1.0
+ */
+func testAlleleFrequencies() {
+	fmt.Println("Checking allele frequencies per gene position.")
+
+	var population = &Population{entities: []DNA{{genes: []rune("AGTC")}, {genes: []rune("ACTG")}, {genes: []rune("AGAC")}, {genes: []rune("ATTA")}}}
+
+	var unnormalized int
+	for pos, frequencies := range PopulationAlleleFrequencies(population) {
+		var sum float32
+		for _, frequency := range frequencies {
+			sum += frequency
+		}
+		if math.Abs(float64(sum)-1.0) > 1e-6 {
+			fmt.Println("FAIL: frequencies at position", pos, "sum to", sum)
+			unnormalized++
+		}
+	}
+	if unnormalized == 0 {
+		fmt.Println("PASS: the frequencies of each position sum to 1.0")
+	}
+
+	var frequencies, err = AlleleFrequencyMap(population, 0)
+	if err == nil && reflect.DeepEqual(frequencies, map[rune]float32{'A': 1.0}) {
+		fmt.Println("PASS: a position holding only A has frequencies", frequencies)
+	} else {
+		fmt.Println("FAIL: a position holding only A has frequencies", frequencies, "error:", err)
+	}
+
+	if _, err = AlleleFrequencyMap(population, 4); err != nil {
+		fmt.Println("PASS: a position past the genes is out of range:", err)
+	} else {
+		fmt.Println("FAIL: a position past the genes had frequencies")
+	}
+}
+
+/**
+ * Schema Check
+ * Checks that a schema of wildcards matches every entity, and an exact schema
+ * only identical entities
+ */
+func testSchema() {
+	fmt.Println("Checking schema matching.")
+
+	var population = &Population{entities: []DNA{
+		{genes: []rune("abcd"), fitness: 0.2},
+		{genes: []rune("abcd"), fitness: 0.4},
+		{genes: []rune("abzz"), fitness: 0.9},
+		{genes: []rune("wxyz"), fitness: 0.1},
+	}}
+
+	var wildcards = Schema{Pattern: []rune("****"), WildCard: '*'}
+	if count := SchemaMatchCount(population, wildcards); count == len(population.entities) {
+		fmt.Println("PASS: a schema of wildcards matches all", count, "entities")
+	} else {
+		fmt.Println("FAIL: a schema of wildcards matches", count, "entities")
+	}
+
+	var exact = Schema{Pattern: []rune("abcd"), WildCard: '*'}
+	var average = SchemaAverageFitness(population, exact)
+	if count := SchemaMatchCount(population, exact); count == 2 && math.Abs(float64(average)-0.3) < 1e-6 {
+		fmt.Println("PASS: an exact schema matches the 2 identical entities, of average fitness", average)
+	} else {
+		fmt.Println("FAIL: an exact schema matches", count, "entities, of average fitness", average)
+	}
+
+	var prefix = Schema{Pattern: []rune("ab**"), WildCard: '*'}
+	if count := SchemaMatchCount(population, prefix); count == 3 {
+		fmt.Println("PASS: schema ab** matches 3 entities")
+	} else {
+		fmt.Println("FAIL: schema ab** matches", count, "entities")
+	}
+}
+
+/**
+ * Mutation Audit Check
+ * Checks that every gene position is audited at a mutation rate of 1.0, and
+ * that nothing is at a rate of 0.0
+ */
+func testMutationAudit() {
+	fmt.Println("Checking mutations are audited.")
+
+	for _, rate := range []float32{1.0, 0.0} {
+		var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: rate, CrossoverRate: 1.0, Seed: 42, AuditMutations: true})
+		if err != nil {
+			fmt.Println("FAIL: could not create population:", err)
+			return
+		}
+		evolve(population)
+
+		var audited int
+		for pos := range []rune(target) {
+			if len(population.Audit.EventsForGene(pos)) > 0 {
+				audited++
+			}
+		}
+		var events = population.Audit.EventsForGeneration(1)
+
+		var unplaced int
+		for _, event := range events {
+			if event.EntityIndex < 0 || event.EntityIndex >= len(population.entities) {
+				unplaced++
+			}
+		}
+
+		if rate == 1.0 && audited == len([]rune(target)) && len(events) == len(population.Audit.Events) && unplaced == 0 {
+			fmt.Println("PASS: at mutation rate 1.0 all", audited, "gene positions were audited in", len(events), "events")
+		} else if rate == 0.0 && len(population.Audit.Events) == 0 {
+			fmt.Println("PASS: at mutation rate 0.0 no events were audited")
+		} else {
+			fmt.Println("FAIL: at mutation rate", rate, audited, "gene positions were audited in", len(population.Audit.Events), "events,", unplaced, "unplaced")
+		}
+	}
+}
+
+/**
+ * Phase Timer Check
+ * Checks that a fresh timer averages 0, and that evolving a population times
+ * every phase (see TestPhaseTimerAverages for recorded timings)
+ */
+func testPhaseTimer() {
+	fmt.Println("Checking phase timer averages.")
+
+	if average := (PhaseTimer{}).AveragePerGeneration(); average == (PhaseTimer{}) {
+		fmt.Println("PASS: a fresh timer averages 0")
+	} else {
+		fmt.Println("FAIL: a fresh timer averages", average)
+	}
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+	err = RunN(context.Background(), 3, population)
+
+	if average := population.Timer.AveragePerGeneration(); err == nil && population.Timer.Generations == 3 && average.Selection > 0 && average.Generate > 0 && average.Fitness > 0 {
+		fmt.Println("PASS: evolving 3 generations timed each phase:", average.Selection, average.Generate, average.Fitness)
+	} else {
+		fmt.Println("FAIL: evolving timed", population.Timer.Generations, "generations, error:", err)
+	}
+}
+
+/**
+ * Run Epochs Check
+ * Checks that the mutation rate of each epoch is applied for its generations,
+ * that the first epoch need only match the settings an epoch changes, and that
+ * a later epoch's seed and mutation audit are applied
+ */
+func testRunEpochs() {
+	fmt.Println("Checking epochs swap configs between generations.")
+
+	var rates = make(map[int]float32)
+	var record = func(population *Population) {
+		rates[population.generations] = population.cfg.MutationRate
+	}
+
+	var explore = Config{Target: target, MaxPop: 50, MutationRate: 0.1, CrossoverRate: 1.0, Seed: 42, OnGenerationEnd: record}
+	var exploit = explore
+	exploit.MutationRate = 0.01
+
+	var population, err = NewPopulation(explore)
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	err = RunEpochs(context.Background(), []Epoch{{10, explore}, {10, exploit}}, population)
+
+	if err == nil && population.generations == 20 && rates[10] == 0.1 && rates[11] == 0.01 && rates[20] == 0.01 {
+		fmt.Println("PASS: generations 1-10 mutated at", rates[10], "and 11-20 at", rates[20])
+	} else {
+		fmt.Println("FAIL: after", population.generations, "generations, rates were", rates, "error:", err)
+	}
+
+	if err = RunEpochs(context.Background(), nil, population); err != nil {
+		fmt.Println("PASS: running no epochs is rejected:", err)
+	} else {
+		fmt.Println("FAIL: running no epochs was accepted")
+	}
+
+	// The population now evolves with the exploit config, so must start from it
+	if err = RunEpochs(context.Background(), []Epoch{{10, explore}}, population); err != nil && population.generations == 20 {
+		fmt.Println("PASS: a first epoch unlike the population's config is rejected:", err)
+	} else {
+		fmt.Println("FAIL: a first epoch unlike the population's config was accepted")
+	}
+
+	// An adaptor may have rewritten the rate, and functions are not compared
+	population.cfg.MutationRate = 0.5
+	var quiet = exploit
+	quiet.OnGenerationEnd = func(population *Population) {}
+	if err = RunEpochs(context.Background(), []Epoch{{1, quiet}}, population); err == nil && population.generations == 21 {
+		fmt.Println("PASS: a first epoch differing in its adapted rate and functions was accepted")
+	} else {
+		fmt.Println("FAIL: a first epoch differing in its adapted rate and functions was rejected:", err)
+	}
+
+	var reseeded = func(seed int64) (string, *MutationAudit, error) {
+		var first = Config{Target: target, MaxPop: 50, MutationRate: 0.1, CrossoverRate: 1.0, Seed: 42}
+		var second = first
+		second.Seed, second.AuditMutations = seed, true
+
+		var population, err = NewPopulation(first)
+		if err != nil {
+			return "", nil, err
+		}
+		err = RunEpochs(context.Background(), []Epoch{{5, first}, {5, second}}, population)
+		return PopulationAllPhrases(population, 0), population.Audit, err
+	}
+	var seven, audit, sevenErr = reseeded(7)
+	var eight, _, eightErr = reseeded(8)
+
+	if sevenErr == nil && eightErr == nil && seven != eight && audit != nil && len(audit.EventsForGeneration(10)) > 0 {
+		fmt.Println("PASS: second epochs seeded 7 and 8 evolved differently, and audited their mutations")
+	} else {
+		fmt.Println("FAIL: second epochs seeded 7 and 8 evolved alike or went unaudited, errors:", sevenErr, eightErr)
+	}
+}
+
+/**
+ * Sort Entities Check
+ * Checks that sorting orders entities by descending fitness, and keeps the
+ * relative order of entities of equal fitness
+ */
+func testSortEntities() {
+	fmt.Println("Checking entities are stably sorted by fitness.")
+
+	var population = &Population{entities: []DNA{
+		{genes: []rune("a"), fitness: 0.5},
+		{genes: []rune("b"), fitness: 0.5},
+		{genes: []rune("c"), fitness: 0.9},
+		{genes: []rune("d"), fitness: 0.5},
+		{genes: []rune("e"), fitness: 0.5},
+	}}
+
+	var sortedBefore = population.IsSorted()
+	population.SortEntities()
+
+	if phrases := PopulationAllPhrases(population, 0); !sortedBefore && population.IsSorted() && phrases == "c\na\nb\nd\ne" {
+		fmt.Println("PASS: entities of equal fitness kept their order after the best")
+	} else {
+		fmt.Println("FAIL: entities were sorted to", strings.Fields(phrases), "sorted before:", sortedBefore)
+	}
+}
+
+/**
+ * Genome Check
+ * Checks that every kind of DNA can be handled as a Genome, cloning to an
+ * independent copy of the same length
+ */
+func testGenome() {
+	fmt.Println("Checking every kind of DNA is a Genome.")
+
+	var floatDNA = FloatDNA{genes: []float64{0.1, 0.2, 0.3}}
+	var rng = NewPRNG(42)
+	var packed = PackedBinaryDNACreate(rng, 70)
+	var diploid DiploidDNA
+	dnaCreateDiploid(rng, &diploid, 5, PrintableASCII)
+	var dna = DNAFromString("genetic")
+	var gp = GPCreate(rng, 3, []string{"+", "*"}, []float64{1, 2})
+
+	for _, genome := range []Genome{&dna, &floatDNA, &packed, &diploid, &gp} {
+		genome.SetFitness(0.5)
+		var clone = genome.CloneGenome()
+		genome.SetFitness(0.9)
+
+		if clone.Fitness() == 0.5 && clone.Len() == genome.Len() && clone != genome {
+			fmt.Printf("PASS: %T cloned to an independent genome of length %d\n", genome, clone.Len())
+		} else {
+			fmt.Printf("FAIL: %T cloned to fitness %v and length %d\n", genome, clone.Fitness(), clone.Len())
+		}
+	}
+}
+
+/**
+ * New Population From Parents Check
+ * Checks that a population bred from parents is full, with the parents' gene
+ * length, and that too few parents are rejected
+ */
+func testNewPopulationFromParents() {
+	fmt.Println("Checking populations can be bred from parents.")
+
+	var parents = []DNA{
+		DNAFromString("I think, therefore I ab."),
+		DNAFromString("I thinx, therefore I am."),
+		DNAFromString("You think, therefore am."),
+	}
+
+	var population, err = NewPopulationFromParents(parents, Config{Target: target, MaxPop: 100, MutationRate: mutrate, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var mislength = CountIf(population, func(dna *DNA) bool {
+		return len(dna.genes) != len(parents[0].genes)
+	})
+
+	if len(population.entities) == 100 && mislength == 0 && population.bestFitness > 0.9 {
+		fmt.Println("PASS: 100 entities were bred from the parents, the best of fitness", population.bestFitness)
+	} else {
+		fmt.Println("FAIL:", len(population.entities), "entities were bred,", mislength, "of the wrong length")
+	}
+
+	if _, err = NewPopulationFromParents(parents[:1], Config{Target: target, MaxPop: 100}); err != nil {
+		fmt.Println("PASS: a single parent is rejected:", err)
+	} else {
+		fmt.Println("FAIL: a single parent was accepted")
+	}
+}
+
+/**
+ * Weighted Average Fitness Check
+ * Checks that uniform weights give the plain average, and that inverse age
+ * weights count older entities for less
+ */
+func testWeightedAverageFitness() {
+	fmt.Println("Checking weighted average fitness.")
+
+	var population = &Population{entities: []DNA{
+		{fitness: 0.9, Age: 9},
+		{fitness: 0.2},
+		{fitness: 0.4, Age: 1},
+	}}
+
+	var uniform, err = populationAverageFitnessWeighted(population, UniformWeights(len(population.entities)))
+	if average := populationAverageFitness(population); err == nil && math.Abs(float64(uniform-average)) < 1e-6 {
+		fmt.Println("PASS: uniform weights give the average fitness", uniform)
+	} else {
+		fmt.Println("FAIL: uniform weights give", uniform, "not the average fitness", average, "error:", err)
+	}
+
+	var byAge float32
+	byAge, err = populationAverageFitnessWeighted(population, InverseAgeWeights(population.entities))
+	if err == nil && byAge < uniform {
+		fmt.Println("PASS: down-weighting the old, fit entity lowers the average to", byAge)
+	} else {
+		fmt.Println("FAIL: inverse age weights give", byAge, "error:", err)
+	}
+
+	if _, err = populationAverageFitnessWeighted(population, UniformWeights(2)); err != nil {
+		fmt.Println("PASS: too few weights are rejected:", err)
+	} else {
+		fmt.Println("FAIL: too few weights were accepted")
+	}
+}
+
+/**
+ * Test Recover
+ * Runs the given check, treating a panic as a failure
+ */
+func testRecover(check func() bool) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Panic:", r)
+			ok = false
+		}
+	}()
+
+	return check()
+}
+
+/**
+ * Concurrent Snapshot Check
+ * Checks that snapshots taken while another goroutine evolves the population
+ * are whole generations, never going backwards. Run the checks with -race to
+ * also catch any unsynchronised access.
+ */
+func testConcurrentSnapshot() {
+	fmt.Println("Checking snapshots are consistent while evolving.")
+
+	var population, err = NewPopulation(Config{Target: target, MaxPop: 50, MutationRate: mutrate, CrossoverRate: 1.0})
+	if err != nil {
+		fmt.Println("FAIL: could not create population:", err)
+		return
+	}
+
+	var done = make(chan error)
+	go func() {
+		for i := 0; i < 100; i++ {
+			if err := evolve(population); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	var snapshots, last int
+	var failure string
+	for evolving := true; evolving; snapshots++ {
+		select {
+		case err = <-done:
+			evolving = false
+		default:
+		}
+
+		var snapshot = population.Snapshot()
+		if len(snapshot.Entities) != 50 && failure == "" {
+			failure = fmt.Sprint("a snapshot had ", len(snapshot.Entities), " entities, not 50")
+		}
+		if snapshot.Generation < last && failure == "" {
+			failure = fmt.Sprint("a snapshot went back from generation ", last, " to ", snapshot.Generation)
+		}
+		last = snapshot.Generation
+	}
+
+	if err != nil {
+		fmt.Println("FAIL: evolving stopped with", err)
+	} else if failure != "" {
+		fmt.Println("FAIL:", failure)
+	} else {
+		fmt.Println("PASS:", snapshots, "snapshots taken while evolving were whole and in order, up to generation", last)
+	}
+}
+
+/**
+ * Random Int Generator with Range Restriction
+ * Generates a random int within the given min and max parameters
+ * Uses the global PRNG
+ */
+func random(min, max int) int {
+	return GlobalPRNG.Int(min, max)
+}
+
+/**
+ * Random Float Generator with Range Restriction
+ * Generates a random float within the given min and max parameters
+ * Uses the global PRNG
+ */
+func randomFloat(min, max float32) float32 {
+	return GlobalPRNG.Float32(min, max)
+}
+
+/**
+ * PRNG: Int
+ * Generates a random int within the given min and max parameters
+ */
+func (p *PRNG) Int(min, max int) int {
+	if p == nil || p.src == nil {
+		return rand.Intn(max-min) + min
+	}
+	return p.src.Intn(max-min) + min
+}
+
+/**
+ * PRNG: Float32
+ * Generates a random float within the given min and max parameters
+ */
+func (p *PRNG) Float32(min, max float32) float32 {
+	if p == nil || p.src == nil {
+		return rand.Float32()*(max-min) + min
+	}
+	return p.src.Float32()*(max-min) + min
+}
+
+/**
+ * PRNG: Float64
+ * Generates a random float64 within the given min and max parameters
+ */
+func (p *PRNG) Float64(min, max float64) float64 {
+	if p == nil || p.src == nil {
+		return rand.Float64()*(max-min) + min
+	}
+	return p.src.Float64()*(max-min) + min
+}
+
+/**
+ * PRNG: NormFloat64
+ * Generates a normally distributed float64 with mean 0 and standard deviation 1
+ */
+func (p *PRNG) NormFloat64() float64 {
+	if p == nil || p.src == nil {
+		return rand.NormFloat64()
+	}
+	return p.src.NormFloat64()
+}
+
+/**
+ * PRNG: Uint64
+ * Generates a random uint64
+ */
+func (p *PRNG) Uint64() uint64 {
+	if p == nil || p.src == nil {
+		return rand.Uint64()
+	}
+	return p.src.Uint64()
+}
+
+/**
+ * PRNG: Perm
+ * Returns a random permutation of the ints [0, n)
+ */
+func (p *PRNG) Perm(n int) []int {
+	if p == nil || p.src == nil {
+		return rand.Perm(n)
+	}
+	return p.src.Perm(n)
+}
+
+/**
+ * Re-maps a number from one range to another
+ * Danw33's Golang interpretation of the Arduino Math Library map method
+ * @see https://www.arduino.cc/reference/en/language/functions/math/map/
+ */
+func highLowMap(x, inMin, inMax, outMin, outMax float32) float32 {
+	return (x-inMin)*(outMax-outMin)/(inMax-inMin) + outMin
+}
+
+/**
+ * Rune Range
+ * Returns the runes from min up to (but not including) max
+ */
+func runeRange(min, max rune) []rune {
+	var runes []rune
+	for r := min; r < max; r++ {
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+/**
+ * Alphabet: Random Rune
+ * Picks a random rune from the alphabet. An empty alphabet picks from the
+ * printable ASCII range.
+ */
+func (a Alphabet) Random(rng *PRNG) rune {
+	if len(a.Runes) == 0 {
+		return rune(rng.Int(32, 128))
+	}
+	return a.Runes[rng.Int(0, len(a.Runes))]
+}
+
+/**
+ * DNA: Create New, Random DNA
+ * Creates n new DNA genes picked from the given alphabet,
+ * Sets them as the genes array (rune slice) in the given dna struct pointer
+ */
+func dnaCreate(rng *PRNG, dna *DNA, n int, alphabet Alphabet) {
+	dna.genes = make([]rune, 0, n) // Replace any existing genes, so there are exactly n
+	for i := 0; i < n; i++ {
+		dna.genes = append(dna.genes, alphabet.Random(rng)) // Pick from range of chars
+	}
+}
+
+/**
+ * DNA: Create New, Random DNA Satisfying a Constraint
+ * Creates n random genes picked from the given alphabet, rejecting and retrying
+ * those failing the constraint up to maxAttempts times in all
+ * Returns ErrNoFeasibleInitialization if every attempt fails.
+ */
+func ConstrainedDNACreate(rng *PRNG, n int, alphabet Alphabet, constraintFn func([]rune) bool, maxAttempts int) (DNA, error) {
+	var dna DNA
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		dnaCreate(rng, &dna, n, alphabet)
+		if constraintFn(dna.genes) {
+			return dna, nil
+		}
+	}
+
+	return DNA{}, ErrNoFeasibleInitialization{maxAttempts}
+}
+
+/**
+ * DNA: Create New, Random Permutation DNA
+ * Creates n genes by shuffling the given alphabet, so that no rune appears
+ * twice. With n equal to the size of the alphabet, the genes are a permutation
+ * of it; n is clamped to the size of the alphabet.
+ */
+func PermutationDNACreate(rng *PRNG, n int, alphabet Alphabet) DNA {
+	if n > len(alphabet.Runes) {
+		n = len(alphabet.Runes)
+	}
+
+	var dna = DNA{genes: make([]rune, n)}
+	for i, j := range rng.Perm(len(alphabet.Runes))[:n] {
+		dna.genes[i] = alphabet.Runes[j]
+	}
+
+	return dna
+}
+
+/**
+ * DNA From String
+ * Creates DNA with one gene per rune of the given string, yet to be assessed
+ */
+func DNAFromString(s string) DNA {
+	return DNA{genes: []rune(s)}
+}
+
+/**
+ * DNA From Runes
+ * Creates DNA with a copy of the given genes, yet to be assessed
+ */
+func DNAFromRunes(genes []rune) DNA {
+	return DNA{genes: append([]rune(nil), genes...)}
+}
+
+/**
+ * DNA: Extract the genes as a string
+ * Built from the genes rune slice in the given dna pointer
+ */
+func dnaExtractPhrase(dna *DNA) string {
+	return string(dna.genes)
+}
+
+/**
+ * DNA: Extract a range of the genes as a string
+ * Built from the genes at positions start (inclusive) to end (exclusive)
+ * Returns ErrGeneRange if the range lies outside of the genes
+ */
+func ExtractPhraseRange(dna *DNA, start, end int) (string, error) {
+	if start < 0 || end > len(dna.genes) || start > end {
+		return "", ErrGeneRange{start, end, len(dna.genes)}
+	}
+
+	return string(dna.genes[start:end]), nil
+}
+
+/**
+ * DNA: Extract a single gene
+ * Returns the gene at the given position
+ * Returns ErrGeneRange if the position lies outside of the genes
+ */
+func ExtractGeneAt(dna *DNA, pos int) (rune, error) {
+	if pos < 0 || pos >= len(dna.genes) {
+		return 0, ErrGeneRange{pos, pos + 1, len(dna.genes)}
+	}
+
+	return dna.genes[pos], nil
+}
+
+/**
+ * DNA: Fitness Assessment Method
+ * Sets a percentage (float32) of "correct" runes (how close to the target) on
+ * the given dna pointer
+ * The dna must have exactly one gene per rune of the target, otherwise its
+ * fitness is set to 0 and ErrGeneLengthMismatch is returned.
+ */
+func dnaAssessFitness(dna *DNA, target string) error {
+	var score int
+	var runeTarget = []rune(target)
+
+	if len(dna.genes) != len(runeTarget) {
+		dna.fitness = 0
+		return ErrGeneLengthMismatch{len(dna.genes), len(runeTarget)}
+	}
+
+	for i := 0; i < len(dna.genes); i++ {
+		if dna.genes[i] == runeTarget[i] {
+			score++
+		}
+	}
+
+	dna.fitness = float32(score) / float32(len(runeTarget))
+
+	return nil
+}
+
+/**
+ * Scored DNA: Fitness Assessment Method
+ * Assesses the fitness as dnaAssessFitness does, appending it to the fitness
+ * history
+ */
+func (s *ScoredDNA) AssessFitness(target string) error {
+	var err = dnaAssessFitness(&s.DNA, target)
+	s.FitnessHistory = append(s.FitnessHistory, s.fitness)
+
+	return err
+}
+
+/**
+ * Scored DNA: Fitness Growth Rate
+ * Returns the slope of the least-squares line through the fitness history, the
+ * average change in fitness per assessment. Returns 0 with fewer than two
+ * assessments.
+ */
+func (s *ScoredDNA) FitnessGrowthRate() float32 {
+	var n = float64(len(s.FitnessHistory))
+	if n < 2 {
+		return 0
+	}
+
+	var meanX = (n - 1) / 2
+	var meanY float64
+	for _, fitness := range s.FitnessHistory {
+		meanY += float64(fitness)
+	}
+	meanY /= n
+
+	var covariance, variance float64
+	for i, fitness := range s.FitnessHistory {
+		var dx = float64(i) - meanX
+		covariance += dx * (float64(fitness) - meanY)
+		variance += dx * dx
+	}
+
+	return float32(covariance / variance)
+}
+
+/**
+ * Multi Target Fitness
+ * Scores dna against several acceptable targets, so that matching any one of
+ * them solves the population. Use Assess as the config's FitnessFunc, with the
+ * config's Target setting the gene length.
+ */
+type MultiTargetFitness struct {
+	Targets []string
+}
+
+/**
+ * Multi Target Fitness: Assess
+ * Returns the dna's best fitness across all of the targets
+ */
+func (m *MultiTargetFitness) Assess(dna *DNA) float32 {
+	var best float32
+	for _, target := range m.Targets {
+		var scored = DNA{genes: dna.genes}
+		dnaAssessFitness(&scored, target)
+		if scored.fitness > best {
+			best = scored.fitness
+		}
+	}
+
+	return best
+}
+
+/**
+ * Solved Target
+ * Returns the target a completed population matched, being the phrase of its
+ * perfect entity, or an empty string if it has not completed
+ */
+func SolvedTarget(population *Population) string {
+	var best = populationGetBest(population)
+	if !population.completed {
+		return ""
+	}
+
+	return best
+}
+
+/**
+ * Nucleotide Fitness
+ * Returns a fitness function scoring the fraction of bases which match the
+ * target sequence. Only the nucleotide bases (A, T, G, C) can score, and
+ * nothing scores against an empty target.
+ */
+func NucleotideFitness(targetSeq string) FitnessFunc {
+	var runeTarget = []rune(targetSeq)
+
+	return func(dna *DNA) float32 {
+		if len(runeTarget) == 0 {
+			return 0
+		}
+
+		var score int
+		for i := 0; i < len(dna.genes) && i < len(runeTarget); i++ {
+			if dna.genes[i] == runeTarget[i] && isNucleotide(dna.genes[i]) {
+				score++
+			}
+		}
+		return float32(score) / float32(len(runeTarget))
+	}
+}
+
+/**
+ * Is Nucleotide
+ * Reports whether the given rune is one of the nucleotide bases
+ */
+func isNucleotide(r rune) bool {
+	for _, base := range DNA4.Runes {
+		if r == base {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * GC Content
+ * Returns the fraction of genes in the given dna which are G or C bases
+ */
+func GCContent(dna *DNA) float32 {
+	if len(dna.genes) == 0 {
+		return 0
+	}
+
+	var count int
+	for _, gene := range dna.genes {
+		if gene == 'G' || gene == 'C' {
+			count++
+		}
+	}
+
+	return float32(count) / float32(len(dna.genes))
+}
+
+/**
+ * GC Content Fitness
+ * Returns a fitness function which rewards sequences whose GC content is
+ * closest to the target GC content (a fraction between 0.0 and 1.0)
+ */
+func GCContentFitness(targetGC float32) FitnessFunc {
+	return func(dna *DNA) float32 {
+		return 1 - float32(math.Abs(float64(GCContent(dna)-targetGC)))
+	}
+}
+
+/**
+ * Weighted Sum Fitness
+ * Returns a fitness function scalarizing several objectives into one, the sum of
+ * each objective's fitness times its weight. There must be one weight per
+ * objective, and the weights must sum to 1.0 (see NormalizeWeights), otherwise
+ * an ErrInvalidConfig is returned.
+ */
+func WeightedSumFitness(objectives []FitnessFunc, weights []float32) (FitnessFunc, error) {
+	if len(objectives) != len(weights) {
+		return nil, ErrInvalidConfig{"FitnessFunc", "must have one weight per objective"}
+	}
+
+	var sum float64
+	for _, weight := range weights {
+		sum += float64(weight)
+	}
+	if math.Abs(sum-1.0) > 1e-6 {
+		return nil, ErrInvalidConfig{"FitnessFunc", "weights must sum to 1.0"}
+	}
+
+	return func(dna *DNA) float32 {
+		var fitness float32
+		for i, objective := range objectives {
+			fitness += weights[i] * objective(dna)
+		}
+		return fitness
+	}, nil
+}
+
+/**
+ * Normalize Weights
+ * Returns a copy of the given weights scaled to sum to 1.0. Weights summing to
+ * 0 are replaced with equal weights.
+ */
+func NormalizeWeights(weights []float32) []float32 {
+	var sum float64
+	for _, weight := range weights {
+		sum += float64(weight)
+	}
+
+	var normalized = make([]float32, len(weights))
+	for i, weight := range weights {
+		if sum == 0 {
+			normalized[i] = 1 / float32(len(weights))
+		} else {
+			normalized[i] = float32(float64(weight) / sum)
+		}
+	}
+
+	return normalized
+}
+
+/**
+ * Coevolutionary Fitness Wrapper
+ * Returns a fitness function rewarding entities for how far their raw fitness
+ * (from fn) lies above the mean raw fitness of the given population's current
+ * entities, clamped to between 0.0 and 1.0. The mean is worked out once per
+ * generation, on the first evaluation, so the function is safe for concurrent
+ * use (as by an AsyncEvaluator) if fn is. Set it as the population's fitness
+ * function once the population has been created.
+ */
+func CoevolutionaryFitnessWrapper(population *Population, fn FitnessFunc) FitnessFunc {
+	var mean float32
+	var generation = -1
+	var mu sync.Mutex
+
+	return func(dna *DNA) float32 {
+		mu.Lock()
+		if generation != population.generations {
+			mean, generation = 0, population.generations
+			for i := range population.entities {
+				mean += fn(&population.entities[i])
+			}
+			if len(population.entities) > 0 {
+				mean /= float32(len(population.entities))
+			}
+		}
+		var current = mean
+		mu.Unlock()
+
+		return float32(clamp(float64(fn(dna)-current), [2]float64{0, 1}))
+	}
+}
+
+/**
+ * Compression Fitness
+ * Returns a fitness function rewarding compressible genes. The genes are encoded
+ * as bytes and compressed, with fitness being 1 - (compressed size / original size)
+ */
+func CompressionFitness(compressor func([]byte) int) FitnessFunc {
+	return func(dna *DNA) float32 {
+		var data = []byte(string(dna.genes))
+		if len(data) == 0 {
+			return 0
+		}
+
+		var ratio = float32(compressor(data)) / float32(len(data))
+
+		// Compressors add overhead, so incompressible data can exceed its original size
+		return float32(math.Max(0, math.Min(1, float64(1-ratio))))
+	}
+}
+
+/**
+ * Run-Length Compressor
+ * Returns the size of the given data once run-length encoded, as (count, byte)
+ * pairs with runs of at most 255 bytes
+ */
+func RunLengthCompressor(data []byte) int {
+	var size int
+	for i := 0; i < len(data); {
+		var run = 1
+		for i+run < len(data) && data[i+run] == data[i] && run < 255 {
+			run++
+		}
+		size += 2
+		i += run
+	}
+	return size
+}
+
+/**
+ * Zlib Compressor
+ * Returns the size of the given data once compressed with zlib
+ */
+func ZlibCompressor(data []byte) int {
+	var buf bytes.Buffer
+	var w = zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Len()
+}
+
+/**
+ * DNA: Crossover Method
+ * Takes two DNA Parents, and returns a DNA Child that has genes spliced from
+ * both parents. Both parents must have the same number of genes.
+ */
+func dnaCrossover(rng *PRNG, partnerA *DNA, partnerB *DNA) (DNA, error) {
+	// Create a new child
+	var child = DNA{}
+	var err = dnaCrossoverInto(rng, &child, partnerA, partnerB)
+
+	// Return the new child
+	return child, err
+}
+
+/**
+ * DNA: Crossover Into
+ * As dnaCrossover, but writes the spliced genes into the given child, reusing
+ * its gene slice where it is large enough
+ */
+func dnaCrossoverInto(rng *PRNG, child *DNA, partnerA *DNA, partnerB *DNA) error {
+	if len(partnerA.genes) != len(partnerB.genes) {
+		return ErrGeneLengthMismatch{len(partnerA.genes), len(partnerB.genes)}
+	}
+	if len(partnerA.genes) == 0 {
+		child.genes = child.genes[:0]
+		return nil
+	}
+
+	// Pick a midpoint in the genes
+	var midpoint = rng.Int(0, len(partnerA.genes))
+
+	// Start from a copy of partner A's genes, which are kept after the midpoint
+	dnaCopyGenes(child, partnerA)
+	child.fitness = 0
+
+	// Up to the midpoint, take partner B's genes
+	for i := 0; i <= midpoint; i++ {
+		// In Java: child.genes[i] = partnerB.genes[i];
+		child.genes[i] = partnerB.genes[i]
+	}
+
+	return nil
+}
+
+/**
+ * DNA: Greedy Crossover Method
+ * Builds a child position by position, preferring whichever parent's gene
+ * matches the target, and choosing randomly when both or neither match. The
+ * child is therefore at least as fit as either parent.
+ * Genes beyond the length of the shorter parent or the target are taken from
+ * partner A.
+ */
+func dnaGreedyCrossover(rng *PRNG, partnerA, partnerB *DNA, target string) DNA {
+	var runeTarget = []rune(target)
+	var child = partnerA.Clone()
+	child.fitness = 0
+
+	for i := 0; i < len(child.genes) && i < len(partnerB.genes) && i < len(runeTarget); i++ {
+		var matchA, matchB = partnerA.genes[i] == runeTarget[i], partnerB.genes[i] == runeTarget[i]
+		if matchB && !matchA || matchA == matchB && rng.Float32(0.0, 1.0) < 0.5 {
+			child.genes[i] = partnerB.genes[i]
+		}
+	}
+
+	return child
+}
+
+/**
+ * DNA: Copy Genes
+ * Copies the genes of the source into the destination, reusing the destination's
+ * gene slice where it is large enough
+ */
+func dnaCopyGenes(dst *DNA, src *DNA) {
+	if cap(dst.genes) < len(src.genes) {
+		dst.genes = make([]rune, len(src.genes))
+	}
+	dst.genes = dst.genes[:len(src.genes)]
+	copy(dst.genes, src.genes)
+}
+
+/**
+ * DNA: Clone
+ * Returns a deep copy of the dna, with its own genes (rune slice) so that
+ * changes to the copy never affect the original
+ */
+func (d *DNA) Clone() DNA {
+	var genes = make([]rune, len(d.genes))
+	copy(genes, d.genes)
+
+	return DNA{genes: genes, fitness: d.fitness, Age: d.Age, ID: d.ID, ParentIDs: d.ParentIDs, ReproductionMode: d.ReproductionMode}
+}
+
+/**
+ * DNA: Fitness
+ * Returns the assessed fitness, implementing Genome (as do the other kinds of
+ * DNA below)
+ */
+func (d *DNA) Fitness() float32 {
+	return d.fitness
+}
+
+/**
+ * DNA: Set Fitness
+ */
+func (d *DNA) SetFitness(fitness float32) {
+	d.fitness = fitness
+}
+
+/**
+ * DNA: Length
+ * Returns the number of genes
+ */
+func (d *DNA) Len() int {
+	return len(d.genes)
+}
+
+/**
+ * DNA: Clone Genome
+ */
+func (d *DNA) CloneGenome() Genome {
+	var clone = d.Clone()
+	return &clone
+}
+
+/**
+ * Float DNA: Fitness
+ */
+func (d *FloatDNA) Fitness() float32 {
+	return d.fitness
+}
+
+/**
+ * Float DNA: Set Fitness
+ */
+func (d *FloatDNA) SetFitness(fitness float32) {
+	d.fitness = fitness
+}
+
+/**
+ * Float DNA: Length
+ * Returns the number of genes
+ */
+func (d *FloatDNA) Len() int {
+	return len(d.genes)
+}
+
+/**
+ * Float DNA: Clone Genome
+ */
+func (d *FloatDNA) CloneGenome() Genome {
+	return &FloatDNA{genes: append([]float64(nil), d.genes...), fitness: d.fitness}
+}
+
+/**
+ * Packed Binary DNA: Fitness
+ */
+func (d *PackedBinaryDNA) Fitness() float32 {
+	return d.fitness
+}
+
+/**
+ * Packed Binary DNA: Set Fitness
+ */
+func (d *PackedBinaryDNA) SetFitness(fitness float32) {
+	d.fitness = fitness
+}
+
+/**
+ * Packed Binary DNA: Length
+ * Returns the number of bits
+ */
+func (d *PackedBinaryDNA) Len() int {
+	return d.length
+}
+
+/**
+ * Packed Binary DNA: Clone Genome
+ */
+func (d *PackedBinaryDNA) CloneGenome() Genome {
+	return &PackedBinaryDNA{data: append([]uint64(nil), d.data...), length: d.length, fitness: d.fitness}
+}
+
+/**
+ * Diploid DNA: Fitness
+ */
+func (d *DiploidDNA) Fitness() float32 {
+	return d.fitness
+}
+
+/**
+ * Diploid DNA: Set Fitness
+ */
+func (d *DiploidDNA) SetFitness(fitness float32) {
+	d.fitness = fitness
+}
+
+/**
+ * Diploid DNA: Length
+ * Returns the number of genes on each strand
+ */
+func (d *DiploidDNA) Len() int {
+	return len(d.genesA)
+}
+
+/**
+ * Diploid DNA: Clone Genome
+ * The dominance table is shared, not copied
+ */
+func (d *DiploidDNA) CloneGenome() Genome {
+	return &DiploidDNA{
+		genesA:         append([]rune(nil), d.genesA...),
+		genesB:         append([]rune(nil), d.genesB...),
+		dominanceTable: d.dominanceTable,
+		fitness:        d.fitness,
+	}
+}
+
+/**
+ * GP DNA: Fitness
+ */
+func (g *GPDNA) Fitness() float32 {
+	return g.fitness
+}
+
+/**
+ * GP DNA: Set Fitness
+ */
+func (g *GPDNA) SetFitness(fitness float32) {
+	g.fitness = fitness
+}
+
+/**
+ * GP DNA: Length
+ * Returns the number of nodes in the tree
+ */
+func (g *GPDNA) Len() int {
+	return len(gpSlots(&g.root))
+}
+
+/**
+ * GP DNA: Clone Genome
+ */
+func (g *GPDNA) CloneGenome() Genome {
+	var clone = *g
+	clone.root = gpClone(g.root)
+	return &clone
+}
+
+/**
+ * DNA: New ID
+ * Returns a new, unique entity ID. IDs count up from 1. Safe for concurrent use.
+ */
+func dnaNewID() int64 {
+	return atomic.AddInt64(&dnaLastID, 1)
+}
+
+/**
+ * DNA: Mutation Method
+ * Mutates the genes of the given entity to runes from the given alphabet, within
+ * the given mutation rate (probability)
+ * Genes are substituted in place, so the entity must own its genes (as children
+ * from dnaCrossover and Clone do).
+ */
+func dnaMutate(rng *PRNG, entity *DNA, rate float32, alphabet Alphabet) {
+	dnaMutateRecording(rng, entity, rate, alphabet, nil)
+}
+
+/**
+ * DNA: Recording Mutation Method
+ * As dnaMutate, but calls record (if not nil) for each gene the mutation
+ * changed, with its position and its runes before and after
+ */
+func dnaMutateRecording(rng *PRNG, entity *DNA, rate float32, alphabet Alphabet, record func(pos int, oldRune, newRune rune)) {
+	for i := 0; i < len(entity.genes); i++ {
+		if rng.Float32(0.0, 1.0) < rate {
+			// In Java: genes[i] = (char) random(32,128);
+			var oldRune = entity.genes[i]
+			entity.genes[i] = alphabet.Random(rng)
+			if record != nil && entity.genes[i] != oldRune {
+				record(i, oldRune, entity.genes[i])
+			}
+		}
+	}
+}
+
+/**
+ * DNA: Per-Gene Mutation Method
+ * Mutates the genes of the given entity, where rates[i] is the mutation rate
+ * (probability) of gene i, picking new genes from the given alphabet. There
+ * must be exactly one rate per gene.
+ */
+func dnaMutatePerGene(rng *PRNG, entity *DNA, rates []float32, alphabet Alphabet) error {
+	if len(rates) != len(entity.genes) {
+		return ErrGeneLengthMismatch{len(rates), len(entity.genes)}
+	}
+
+	for i := 0; i < len(entity.genes); i++ {
+		if rng.Float32(0.0, 1.0) < rates[i] {
+			entity.genes[i] = alphabet.Random(rng)
+		}
+	}
+
+	return nil
+}
+
+/**
+ * DNA: Variable Rate Mutation Method
+ * Mutates the genes of the given entity, where the mutation rate of each gene is
+ * the base rate scaled by the variance function's result for its position,
+ * picking new genes from the given alphabet
+ */
+func dnaMutateWithVariableRate(rng *PRNG, entity *DNA, baserate float32, varianceFunc func(pos int) float32, alphabet Alphabet) {
+	var rates = make([]float32, len(entity.genes))
+	for i := range rates {
+		rates[i] = baserate * varianceFunc(i)
+	}
+
+	// There is always one rate per gene, so this cannot fail
+	dnaMutatePerGene(rng, entity, rates, alphabet)
+}
+
+/**
+ * DNA: Permutation Repair Method
+ * Repairs an invalid permutation of the given alphabet (as produced by crossing
+ * over two valid permutations) in place. Duplicate genes, and genes outside of
+ * the alphabet, are replaced left to right by the missing runes in alphabet order.
+ */
+func dnaRepairPermutation(entity *DNA, alphabet []rune) *DNA {
+	var seen = make(map[rune]bool, len(alphabet))
+	var valid = make(map[rune]bool, len(alphabet))
+	for _, r := range alphabet {
+		valid[r] = true
+	}
+	for _, r := range entity.genes {
+		seen[r] = true
+	}
+
+	// Runes of the alphabet that do not appear in the genes
+	var missing []rune
+	for _, r := range alphabet {
+		if !seen[r] {
+			missing = append(missing, r)
+		}
+	}
+
+	// Keep the first occurrence of each valid rune, replace everything else
+	var kept = make(map[rune]bool, len(alphabet))
+	for i, r := range entity.genes {
+		if valid[r] && !kept[r] {
+			kept[r] = true
+			continue
+		}
+		if len(missing) > 0 {
+			entity.genes[i] = missing[0]
+			kept[missing[0]] = true
+			missing = missing[1:]
+		}
+	}
+
+	return entity
+}
+
+/**
+ * Packed Binary DNA: Create New, Random Packed Binary DNA
+ * Returns new dna holding n random bits
+ */
+func PackedBinaryDNACreate(rng *PRNG, n int) PackedBinaryDNA {
+	var dna = PackedBinaryDNA{data: make([]uint64, (n+63)/64), length: n}
+
+	for i := range dna.data {
+		dna.data[i] = rng.Uint64()
+	}
+	dna.clearTail()
+
+	return dna
+}
+
+/**
+ * Packed Binary DNA: Get Bit
+ * Returns the bit (gene) at the given position
+ */
+func (d *PackedBinaryDNA) getBit(pos int) bool {
+	return d.data[pos/64]&(1<<uint(pos%64)) != 0
+}
+
+/**
+ * Packed Binary DNA: Set Bit
+ * Sets the bit (gene) at the given position
+ */
+func (d *PackedBinaryDNA) setBit(pos int, val bool) {
+	if val {
+		d.data[pos/64] |= 1 << uint(pos%64)
+	} else {
+		d.data[pos/64] &^= 1 << uint(pos%64)
+	}
+}
+
+/**
+ * Packed Binary DNA: Clear Tail
+ * Zeroes the unused bits of the last word, beyond the dna's length
+ */
+func (d *PackedBinaryDNA) clearTail() {
+	if d.length%64 != 0 {
+		d.data[len(d.data)-1] &= (1 << uint(d.length%64)) - 1
+	}
+}
+
+/**
+ * Packed Binary DNA: Crossover Method
+ * Returns a child taking partner B's bits up to and including a random midpoint,
+ * and partner A's bits after it (as dnaCrossover does), a word at a time. The
+ * partners must be the same length.
+ */
+func PackedBinaryDNACrossover(rng *PRNG, partnerA *PackedBinaryDNA, partnerB *PackedBinaryDNA) (PackedBinaryDNA, error) {
+	if partnerA.length != partnerB.length {
+		return PackedBinaryDNA{}, ErrGeneLengthMismatch{partnerA.length, partnerB.length}
+	}
+
+	var child = PackedBinaryDNA{data: make([]uint64, len(partnerA.data)), length: partnerA.length}
+	if partnerA.length == 0 {
+		return child, nil
+	}
+
+	var midpoint = rng.Int(0, partnerA.length)
+	var word, bit = midpoint / 64, uint(midpoint % 64)
+
+	// Whole words before the midpoint's word come from B, after it from A
+	copy(child.data[:word], partnerB.data[:word])
+	copy(child.data[word+1:], partnerA.data[word+1:])
+
+	// The midpoint's word is split, bits 0..bit from B and the rest from A
+	var mask uint64 = (2 << bit) - 1
+	child.data[word] = (partnerB.data[word] & mask) | (partnerA.data[word] &^ mask)
+
+	return child, nil
+}
+
+/**
+ * Packed Binary DNA: Mutation Method
+ * Flips each bit of the given entity within the given mutation rate (probability)
+ */
+func PackedBinaryDNAMutate(rng *PRNG, entity *PackedBinaryDNA, rate float32) {
+	for i := 0; i < entity.length; i++ {
+		if rng.Float32(0.0, 1.0) < rate {
+			entity.data[i/64] ^= 1 << uint(i%64)
+		}
+	}
+}
+
+/**
+ * Compact GA Step Size
+ * How far each step moves a probability toward the winner's bit; equivalent to
+ * a virtual population of 50 entities
+ */
+const compactGAStep float32 = 1.0 / 50
+
+/**
+ * New Compact GA
+ * Creates a compact GA for phrases of geneLen 8-bit genes, with every bit
+ * equally likely to be set or clear
+ */
+func NewCompactGA(rng *PRNG, geneLen int, target string) *CompactGA {
+	var probVector = make([]float32, geneLen*8)
+	for i := range probVector {
+		probVector[i] = 0.5
+	}
+
+	return &CompactGA{probVector: probVector, target: target, rng: rng}
+}
+
+/**
+ * Compact GA: Step
+ * Samples two candidates from the probability vector, and moves each
+ * probability on which they disagree toward the bit of the winner (the one
+ * matching more bits of the target). Returns the winner, with its fitness
+ * assessed against the target, and whether every probability has converged on
+ * 0 or 1.
+ */
+func (c *CompactGA) Step() (best DNA, converged bool) {
+	var a, b = c.sample(), c.sample()
+	if c.matchingBits(b) > c.matchingBits(a) {
+		a, b = b, a
+	}
+
+	converged = true
+	for i := range c.probVector {
+		var bitA, bitB = a[i/8] >> uint(i%8) & 1, b[i/8] >> uint(i%8) & 1
+		if bitA != bitB {
+			if bitA == 1 {
+				c.probVector[i] = float32(math.Min(float64(c.probVector[i]+compactGAStep), 1))
+			} else {
+				c.probVector[i] = float32(math.Max(float64(c.probVector[i]-compactGAStep), 0))
+			}
+		}
+		if c.probVector[i] > 0 && c.probVector[i] < 1 {
+			converged = false
+		}
+	}
+	c.iterations++
+
+	best.genes = a
+	dnaAssessFitness(&best, c.target)
+
+	return best, converged
+}
+
+/**
+ * Compact GA: Sample
+ * Draws a candidate from the probability vector, one gene per eight bits
+ */
+func (c *CompactGA) sample() []rune {
+	var genes = make([]rune, len(c.probVector)/8)
+	for i, p := range c.probVector {
+		if c.rng.Float32(0.0, 1.0) < p {
+			genes[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return genes
+}
+
+/**
+ * Compact GA: Matching Bits
+ * Counts the bits of the given genes which match those of the target
+ */
+func (c *CompactGA) matchingBits(genes []rune) int {
+	var matching int
+	var runeTarget = []rune(c.target)
+	for i := 0; i < len(genes) && i < len(runeTarget); i++ {
+		matching += 8 - bits.OnesCount8(uint8(genes[i]^runeTarget[i]))
+	}
+	return matching
+}
+
+/**
+ * Diploid DNA: Create New, Random Diploid DNA
+ * Creates n new random alleles, picked from the given alphabet, on each strand
+ * of the given diploid dna pointer
+ */
+func dnaCreateDiploid(rng *PRNG, dna *DiploidDNA, n int, alphabet Alphabet) {
+	for i := 0; i < n; i++ {
+		dna.genesA = append(dna.genesA, alphabet.Random(rng))
+		dna.genesB = append(dna.genesB, alphabet.Random(rng))
+	}
+}
+
+/**
+ * Diploid DNA: Express Phenotype
+ * Returns the expressed genes of the given diploid dna. At each locus the
+ * dominant allele is expressed; when neither allele dominates the other, the
+ * allele on strand A is expressed.
+ */
+func dnaExpressDiploid(d *DiploidDNA) []rune {
+	var expressed = make([]rune, len(d.genesA))
+
+	for i := 0; i < len(d.genesA); i++ {
+		var a, b = d.genesA[i], d.genesB[i]
+		if dominant, ok := d.dominanceTable[a]; ok && dominant == b {
+			// A is recessive to B, so B is expressed
+			expressed[i] = b
+		} else {
+			expressed[i] = a
+		}
+	}
+
+	return expressed
+}
+
+/**
+ * Diploid DNA: Fitness Assessment Method
+ * Assesses the fitness of the expressed phenotype against the target
+ */
+func dnaAssessDiploidFitness(d *DiploidDNA, target string) error {
+	var phenotype = DNA{genes: dnaExpressDiploid(d)}
+	var err = dnaAssessFitness(&phenotype, target)
+	d.fitness = phenotype.fitness
+	return err
+}
+
+/**
+ * Diploid DNA: Crossover Method
+ * Each parent forms a gamete by swapping the segments of its two strands after
+ * a random midpoint, the child then receives one gamete from each parent.
+ */
+func dnaCrossoverDiploid(rng *PRNG, partnerA *DiploidDNA, partnerB *DiploidDNA) DiploidDNA {
+	var child = DiploidDNA{dominanceTable: partnerA.dominanceTable}
+
+	child.genesA = dnaDiploidGamete(rng, partnerA)
+	child.genesB = dnaDiploidGamete(rng, partnerB)
+
+	return child
+}
+
+/**
+ * Diploid DNA: Gamete Formation
+ * Returns a single strand made up of strand A before a random midpoint, and
+ * strand B after it (or vice versa). Strands of no genes form an empty gamete.
+ */
+func dnaDiploidGamete(rng *PRNG, d *DiploidDNA) []rune {
+	var first, second = d.genesA, d.genesB
+	if rng.Int(0, 2) == 1 {
+		first, second = second, first
+	}
+
+	var gamete = make([]rune, len(first))
+	if len(first) == 0 {
+		return gamete
+	}
+
+	var midpoint = rng.Int(0, len(first))
+	copy(gamete[:midpoint], first[:midpoint])
+	copy(gamete[midpoint:], second[midpoint:])
+
+	return gamete
+}
+
+/**
+ * Diploid DNA: Mutation Method
+ * Mutates each strand of the given diploid entity independently, within the
+ * given mutation rate (probability), picking new alleles from the given alphabet
+ */
+func dnaMutateDiploid(rng *PRNG, entity *DiploidDNA, rate float32, alphabet Alphabet) {
+	for i := 0; i < len(entity.genesA); i++ {
+		if rng.Float32(0.0, 1.0) < rate {
+			entity.genesA[i] = alphabet.Random(rng)
+		}
+		if rng.Float32(0.0, 1.0) < rate {
+			entity.genesB[i] = alphabet.Random(rng)
+		}
+	}
+}
+
+/**
+ * Self-Adaptive DNA: Create New, Random Self-Adaptive DNA
+ * Creates n new random genes, picked from the given alphabet, with the given
+ * initial mutation rate
+ */
+func dnaCreateSelfAdaptive(rng *PRNG, dna *SelfAdaptiveDNA, n int, rate float32, alphabet Alphabet) {
+	for i := 0; i < n; i++ {
+		dna.genes = append(dna.genes, alphabet.Random(rng))
+	}
+	dna.logSigma = float32(math.Log(float64(rate)))
+}
+
+/**
+ * Self-Adaptive DNA: Fitness Assessment Method
+ * Assesses the fitness of the genes against the target
+ */
+func dnaAssessSelfAdaptiveFitness(dna *SelfAdaptiveDNA, target string) error {
+	var plain = DNA{genes: dna.genes}
+	var err = dnaAssessFitness(&plain, target)
+	dna.fitness = plain.fitness
+	return err
+}
+
+/**
+ * Self-Adaptive DNA: Crossover Method
+ * Splices the genes of both parents as dnaCrossover does, the child's mutation
+ * rate is the (log) average of both parents' rates
+ */
+func dnaCrossoverSelfAdaptive(rng *PRNG, partnerA *SelfAdaptiveDNA, partnerB *SelfAdaptiveDNA) (SelfAdaptiveDNA, error) {
+	var plainA, plainB = DNA{genes: partnerA.genes}, DNA{genes: partnerB.genes}
+	var child, err = dnaCrossover(rng, &plainA, &plainB)
+
+	return SelfAdaptiveDNA{genes: child.genes, logSigma: (partnerA.logSigma + partnerB.logSigma) / 2}, err
+}
+
+/**
+ * Self-Adaptive DNA: Mutation Method
+ * First perturbs the entity's own mutation rate by tau * N(0,1) in log space
+ * (where tau = 1/sqrt(2n)), then mutates the genes with the resulting rate,
+ * picking new genes from the given alphabet
+ */
+func dnaMutateSelfAdaptive(rng *PRNG, entity *SelfAdaptiveDNA, alphabet Alphabet) {
+	var tau = 1 / math.Sqrt(2*float64(len(entity.genes)))
+	entity.logSigma += float32(tau * rng.NormFloat64())
+
+	// A rate above 1.0 has no meaning, so cap the log of the rate at 0
+	if entity.logSigma > 0 {
+		entity.logSigma = 0
+	}
+
+	var rate = float32(math.Exp(float64(entity.logSigma)))
+	for i := 0; i < len(entity.genes); i++ {
+		if rng.Float32(0.0, 1.0) < rate {
+			entity.genes[i] = alphabet.Random(rng)
+		}
+	}
+}
+
+/**
+ * GP: Create
+ * Grows a random expression tree of at most maxDepth levels below the root, from
+ * the given operators (see gpArity) and terminals. Leaves are the variable x or
+ * one of the terminal constants.
+ */
+func GPCreate(rng *PRNG, maxDepth int, ops []string, terminals []float64) GPDNA {
+	return GPDNA{root: gpGrow(rng, maxDepth, ops, terminals), maxDepth: maxDepth, ops: ops, terminals: terminals}
+}
+
+/**
+ * GP: Grow
+ * Grows a random subtree of at most maxDepth levels, ending each branch early
+ * with a terminal half of the time
+ */
+func gpGrow(rng *PRNG, maxDepth int, ops []string, terminals []float64) *GPNode {
+	if maxDepth <= 0 || len(ops) == 0 || rng.Float32(0.0, 1.0) < 0.5 {
+		if len(terminals) == 0 || rng.Float32(0.0, 1.0) < 0.5 {
+			return &GPNode{Op: "x"}
+		}
+		return &GPNode{Op: "const", Value: terminals[rng.Int(0, len(terminals))]}
+	}
+
+	var op = ops[rng.Int(0, len(ops))]
+	var node = &GPNode{Op: op, Arity: gpArity[op]}
+	for i := 0; i < node.Arity; i++ {
+		node.Children = append(node.Children, gpGrow(rng, maxDepth-1, ops, terminals))
+	}
+
+	return node
+}
+
+/**
+ * GP: Evaluate
+ * Evaluates the expression tree for the given value of x
+ * Division by zero is protected, evaluating to 1.
+ */
+func GPEvaluate(root *GPNode, x float64) float64 {
+	switch root.Op {
+	case "x":
+		return x
+	case "const":
+		return root.Value
+	case "+":
+		return GPEvaluate(root.Children[0], x) + GPEvaluate(root.Children[1], x)
+	case "-":
+		return GPEvaluate(root.Children[0], x) - GPEvaluate(root.Children[1], x)
+	case "*":
+		return GPEvaluate(root.Children[0], x) * GPEvaluate(root.Children[1], x)
+	case "/":
+		var divisor = GPEvaluate(root.Children[1], x)
+		if divisor == 0 {
+			return 1
+		}
+		return GPEvaluate(root.Children[0], x) / divisor
+	case "sin":
+		return math.Sin(GPEvaluate(root.Children[0], x))
+	case "cos":
+		return math.Cos(GPEvaluate(root.Children[0], x))
+	}
+
+	return 0
+}
+
+/**
+ * GP: Crossover Subtree
+ * Copies partner A's tree, replacing a random subtree of it with a copy of a
+ * random subtree of partner B
+ */
+func GPCrossoverSubtree(rng *PRNG, partnerA *GPDNA, partnerB *GPDNA) GPDNA {
+	var child = GPDNA{root: gpClone(partnerA.root), maxDepth: partnerA.maxDepth, ops: partnerA.ops, terminals: partnerA.terminals}
+
+	var slots = gpSlots(&child.root)
+	var donors = gpSlots(&partnerB.root)
+	*slots[rng.Int(0, len(slots))] = gpClone(*donors[rng.Int(0, len(donors))])
+
+	return child
+}
+
+/**
+ * GP: Mutation Method
+ * With probability rate, replaces a random subtree of the entity with a newly
+ * grown one
+ */
+func GPMutate(rng *PRNG, entity *GPDNA, rate float32) {
+	if rng.Float32(0.0, 1.0) >= rate {
+		return
+	}
+
+	var slots = gpSlots(&entity.root)
+	*slots[rng.Int(0, len(slots))] = gpGrow(rng, entity.maxDepth, entity.ops, entity.terminals)
+}
+
+/**
+ * GP: Clone
+ * Deep copies a subtree
+ */
+func gpClone(node *GPNode) *GPNode {
+	var clone = *node
+	clone.Children = make([]*GPNode, len(node.Children))
+	for i, child := range node.Children {
+		clone.Children[i] = gpClone(child)
+	}
+
+	return &clone
+}
+
+/**
+ * GP: Slots
+ * Lists the places in the tree holding a node, starting with the root, so that
+ * any subtree can be replaced
+ */
+func gpSlots(slot **GPNode) []**GPNode {
+	var slots = []**GPNode{slot}
+	for i := range (*slot).Children {
+		slots = append(slots, gpSlots(&(*slot).Children[i])...)
+	}
+
+	return slots
+}
+
+/**
+ * GP: Valid
+ * Reports whether every node of the tree is a known operator or terminal with
+ * as many children as its arity
+ */
+func gpValid(node *GPNode) bool {
+	if node == nil {
+		return false
+	}
+
+	var arity, known = gpArity[node.Op]
+	if !known || node.Arity != arity || len(node.Children) != arity {
+		return false
+	}
+
+	for _, child := range node.Children {
+		if !gpValid(child) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/**
+ * Decision Tree: Gene Length
+ * Returns the number of genes encoding a complete decision tree of maxDepth
+ * levels below the root: two per internal node and one per leaf
+ */
+func DecisionTreeGeneLength(maxDepth int) int {
+	var leaves = 1 << uint(maxDepth)
+	return 2*(leaves-1) + leaves
+}
+
+/**
+ * Decision Tree: Create
+ * Creates random DNA encoding a complete decision tree of maxDepth levels. Each
+ * internal node is a (feature index, threshold) gene pair, the threshold being
+ * a fraction (of 256) of the way through the feature's range, and each leaf is
+ * a class label gene.
+ */
+func DecisionTreeDNACreate(rng *PRNG, maxDepth int, numFeatures int, numClasses int) DNA {
+	var internal = (1 << uint(maxDepth)) - 1
+	var dna = DNA{genes: make([]rune, 0, DecisionTreeGeneLength(maxDepth))}
+
+	for i := 0; i < internal; i++ {
+		dna.genes = append(dna.genes, rune(rng.Int(0, numFeatures)), rune(rng.Int(0, 256)))
+	}
+	for i := 0; i <= internal; i++ {
+		dna.genes = append(dna.genes, rune(rng.Int(0, numClasses)))
+	}
+
+	return dna
+}
+
+/**
+ * Decision Tree Fitness
+ * Returns a fitness function scoring the accuracy of the dna's decision tree on
+ * the training data X (one row of features per sample) and class labels y.
+ * Genes out of range for the data wrap around, so any DNA of the right length
+ * (see DecisionTreeGeneLength) decodes to a tree.
+ */
+func DecisionTreeFitness(X [][]float32, y []int) FitnessFunc {
+	var ranges = make([][2]float32, 0)
+	var numClasses = 1
+	for i, row := range X {
+		for f, value := range row {
+			if f >= len(ranges) {
+				ranges = append(ranges, [2]float32{value, value})
+			}
+			ranges[f][0] = float32(math.Min(float64(ranges[f][0]), float64(value)))
+			ranges[f][1] = float32(math.Max(float64(ranges[f][1]), float64(value)))
+		}
+		if y[i] >= numClasses {
+			numClasses = y[i] + 1
+		}
+	}
+
+	return func(dna *DNA) float32 {
+		if len(X) == 0 || len(ranges) == 0 || len(dna.genes) == 0 {
+			return 0
+		}
+
+		var tree = decisionTreeDecode(dna, ranges, numClasses)
+		var correct int
+		for i, row := range X {
+			if tree.Classify(row) == y[i] {
+				correct++
+			}
+		}
+
+		return float32(correct) / float32(len(X))
+	}
+}
+
+/**
+ * Decision Tree: Decode
+ * Decodes the dna's genes into a decision tree for data with the given feature
+ * ranges and number of classes
+ */
+func decisionTreeDecode(dna *DNA, ranges [][2]float32, numClasses int) DecisionTreeDNA {
+	// Solve len = 3 * leaves - 2 for the number of leaves, ignoring spare genes
+	var leaves = 1
+	for 3*leaves*2-2 <= len(dna.genes) {
+		leaves *= 2
+	}
+
+	var tree DecisionTreeDNA
+	for i := 0; i < leaves-1; i++ {
+		var feature = int(dna.genes[2*i]) % len(ranges)
+		var fraction = float32(int(dna.genes[2*i+1])%256) / 256
+		tree.Features = append(tree.Features, feature)
+		tree.Thresholds = append(tree.Thresholds, ranges[feature][0]+fraction*(ranges[feature][1]-ranges[feature][0]))
+	}
+	for i := 0; i < leaves; i++ {
+		tree.Labels = append(tree.Labels, int(dna.genes[2*(leaves-1)+i])%numClasses)
+	}
+
+	return tree
+}
+
+/**
+ * Decision Tree DNA: Classify
+ * Follows the sample's features from the root to a leaf, returning its class
+ */
+func (t DecisionTreeDNA) Classify(x []float32) int {
+	var node = 0
+	for node < len(t.Features) {
+		if x[t.Features[node]] <= t.Thresholds[node] {
+			node = 2*node + 1
+		} else {
+			node = 2*node + 2
+		}
+	}
+
+	return t.Labels[node-len(t.Features)]
+}
+
+/**
+ * Feature Selection Fitness
+ * Returns a fitness function reading binary dna (genes from the Binary alphabet,
+ * one per feature) as a mask of the features to include. The selected columns
+ * of X are passed to the evaluator with their feature indexes, and its score is
+ * reduced by up to 0.1 as more of the features are selected.
+ */
+func FeatureSelectionFitness(X [][]float32, y []int, evaluator func(features []int, X [][]float32, y []int) float32) FitnessFunc {
+	const penaltyWeight = 0.1
+
+	return func(dna *DNA) float32 {
+		var features []int
+		for i, gene := range dna.genes {
+			if gene == '1' {
+				features = append(features, i)
+			}
+		}
+
+		var selected = make([][]float32, len(X))
+		for i, row := range X {
+			selected[i] = make([]float32, 0, len(features))
+			for _, f := range features {
+				if f < len(row) {
+					selected[i] = append(selected[i], row[f])
+				}
+			}
+		}
+
+		var fitness = evaluator(features, selected, y)
+		if len(dna.genes) > 0 {
+			fitness -= penaltyWeight * float32(len(features)) / float32(len(dna.genes))
+		}
+
+		return float32(math.Max(0, float64(fitness)))
+	}
+}
+
+/**
+ * Variance Evaluator
+ * A simple feature selection evaluator, scoring the mean variance v of the
+ * selected columns as v / (1 + v). Selecting no features scores 0.
+ */
+func VarianceEvaluator(features []int, X [][]float32, y []int) float32 {
+	if len(features) == 0 || len(X) == 0 || len(X[0]) == 0 {
+		return 0
+	}
+
+	var total float64
+	for f := 0; f < len(X[0]); f++ {
+		var mean float64
+		for _, row := range X {
+			mean += float64(row[f])
+		}
+		mean /= float64(len(X))
+
+		var variance float64
+		for _, row := range X {
+			variance += math.Pow(float64(row[f])-mean, 2)
+		}
+		total += variance / float64(len(X))
+	}
+
+	var meanVariance = total / float64(len(X[0]))
+
+	return float32(meanVariance / (1 + meanVariance))
+}
+
+/**
+ * XOR Training Data
+ * The XOR problem, the minimal non-linearly separable training set
+ */
+var (
+	XORTrainX = [][]float32{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	XORTrainY = []float32{0, 1, 1, 0}
+)
+
+/**
+ * Float DNA: Gene Count for a Neural Network
+ * Returns the number of genes needed to hold the weights of a feedforward
+ * network with the given layer sizes, each neuron having one weight per input
+ * plus a bias
+ */
+func FloatDNALengthForNetwork(layers []int) int {
+	var n int
+	for i := 1; i < len(layers); i++ {
+		n += (layers[i-1] + 1) * layers[i]
+	}
+	return n
+}
+
+/**
+ * Neural Network Fitness
+ * Returns a fitness function which decodes float dna as the weights of a
+ * feedforward network with the given layer sizes (sigmoid activations), and
+ * scores it by the mean squared error of its first output on the training data
+ * as 1 / (1 + MSE).
+ */
+func NeuralNetFitness(layers []int, trainX [][]float32, trainY []float32) FloatFitnessFunc {
+	return func(dna *FloatDNA) float32 {
+		if len(dna.genes) < FloatDNALengthForNetwork(layers) || len(trainX) == 0 {
+			return 0
+		}
+
+		var mse float64
+		for i := range trainX {
+			var output = neuralNetForward(layers, dna.genes, trainX[i])
+			var delta = output[0] - float64(trainY[i])
+			mse += delta * delta
+		}
+		mse /= float64(len(trainX))
+
+		return float32(1 / (1 + mse))
+	}
+}
+
+/**
+ * Neural Network Forward Pass
+ * Feeds the input through the network described by the layer sizes and weights,
+ * returning the output layer's activations
+ */
+func neuralNetForward(layers []int, weights []float64, input []float32) []float64 {
+	var activations = make([]float64, len(input))
+	for i, x := range input {
+		activations[i] = float64(x)
+	}
+
+	var w int
+	for l := 1; l < len(layers); l++ {
+		var next = make([]float64, layers[l])
+		for j := range next {
+			var sum float64
+			for k := 0; k < layers[l-1]; k++ {
+				sum += weights[w] * activations[k]
+				w++
+			}
+			sum += weights[w] // Bias
+			w++
+			next[j] = 1 / (1 + math.Exp(-sum))
+		}
+		activations = next
+	}
+
+	return activations
+}
+
+/**
+ * Rastrigin Bounds
+ * The standard search bounds of each dimension of the Rastrigin function
+ */
+var RastriginBounds = [2]float64{-5.12, 5.12}
+
+/**
+ * Float Bounds
+ * Returns the same lower and upper bound for each of n genes
+ */
+func FloatBounds(n int, bound [2]float64) [][2]float64 {
+	var bounds = make([][2]float64, n)
+	for i := range bounds {
+		bounds[i] = bound
+	}
+	return bounds
+}
+
+/**
+ * Rastrigin Fitness
+ * Returns a fitness function for the n-dimensional Rastrigin function, a
+ * multimodal benchmark with its global minimum at xi = 0, scored as
+ * 1 / (1 + rastrigin(genes)). DNA of fewer than n genes scores 0.
+ */
+func RastriginFitness(n int) FloatFitnessFunc {
+	return func(dna *FloatDNA) float32 {
+		if len(dna.genes) < n {
+			return 0
+		}
+		return float32(1 / (1 + rastriginValue(dna.genes[:n])))
+	}
+}
+
+/**
+ * Rastrigin Value
+ * 10n + sum(xi² - 10cos(2πxi))
+ */
+func rastriginValue(genes []float64) float64 {
+	var value = 10 * float64(len(genes))
+	for _, x := range genes {
+		value += x*x - 10*math.Cos(2*math.Pi*x)
+	}
+	return value
+}
+
+/**
+ * Rosenbrock Bounds
+ * The standard search bounds of each dimension of the Rosenbrock function
+ */
+var RosenbrockBounds = [2]float64{-2.048, 2.048}
+
+/**
+ * Rosenbrock Fitness
+ * Returns a fitness function for the n-dimensional Rosenbrock (banana) function,
+ * whose global minimum at xi = 1 lies in a narrow curved valley, scored as
+ * 1 / (1 + rosenbrock(genes)). DNA of fewer than n genes scores 0.
+ */
+func RosenbrockFitness(n int) FloatFitnessFunc {
+	return func(dna *FloatDNA) float32 {
+		if len(dna.genes) < n {
+			return 0
+		}
+		return float32(1 / (1 + rosenbrockValue(dna.genes[:n])))
+	}
+}
+
+/**
+ * Rosenbrock Value
+ * sum(100(xi+1 - xi²)² + (xi - 1)²)
+ */
+func rosenbrockValue(genes []float64) float64 {
+	var value float64
+	for i := 0; i < len(genes)-1; i++ {
+		var a = genes[i+1] - genes[i]*genes[i]
+		var b = genes[i] - 1
+		value += 100*a*a + b*b
+	}
+	return value
+}
+
+/**
+ * Sphere Fitness
+ * Returns a fitness function for the n-dimensional sphere function sum(xi²), the
+ * simplest unimodal benchmark with its global minimum at the origin. The value
+ * is normalised by its maximum within the given bounds, so fitness is
+ * 1 - sphere(genes) / max sphere(bounds). DNA of fewer than n genes scores 0.
+ * Panics if given fewer than n bounds, as the maximum cannot be found without them.
+ */
+func SphereFitness(n int, bounds [][2]float64) FloatFitnessFunc {
+	if len(bounds) < n {
+		panic(fmt.Sprintf("SphereFitness: %d dimensions need %d bounds, only %d given", n, n, len(bounds)))
+	}
+
+	var max float64
+	for i := 0; i < n; i++ {
+		max += math.Max(bounds[i][0]*bounds[i][0], bounds[i][1]*bounds[i][1])
+	}
+
+	return func(dna *FloatDNA) float32 {
+		if len(dna.genes) < n {
+			return 0
+		}
+
+		var value float64
+		for _, x := range dna.genes[:n] {
+			value += x * x
+		}
+		return float32(1 - value/max)
+	}
+}
+
+/**
+ * Float DNA: Create New, Random Float DNA
+ * Creates one random gene within each of the given bounds
+ */
+func dnaCreateFloat(rng *PRNG, dna *FloatDNA, bounds [][2]float64) {
+	for _, bound := range bounds {
+		dna.genes = append(dna.genes, rng.Float64(bound[0], bound[1]))
+	}
+}
+
+/**
+ * Float DNA: BLX-alpha Crossover Method
+ * Each child gene is picked uniformly from the range spanned by both parents'
+ * genes, extended by alpha times its width on either side, then clamped to bounds
+ */
+func dnaCrossoverBLX(rng *PRNG, partnerA *FloatDNA, partnerB *FloatDNA, alpha float64, bounds [][2]float64) FloatDNA {
+	var child = FloatDNA{genes: make([]float64, len(partnerA.genes))}
+
+	for i := range child.genes {
+		var low = math.Min(partnerA.genes[i], partnerB.genes[i])
+		var high = math.Max(partnerA.genes[i], partnerB.genes[i])
+		var extent = alpha * (high - low)
+
+		child.genes[i] = clamp(rng.Float64(low-extent, high+extent), bounds[i])
+	}
+
+	return child
+}
+
+/**
+ * Float DNA: Gaussian Mutation Method
+ * Adds N(0, sigma) noise, where sigma is a fraction of the gene's range, to each
+ * gene within the given mutation rate (probability), clamped to bounds
+ */
+func dnaMutateGaussian(rng *PRNG, entity *FloatDNA, rate float32, sigma float64, bounds [][2]float64) {
+	for i := range entity.genes {
+		if rng.Float32(0.0, 1.0) < rate {
+			var width = bounds[i][1] - bounds[i][0]
+			entity.genes[i] = clamp(entity.genes[i]+rng.NormFloat64()*sigma*width, bounds[i])
+		}
+	}
+}
+
+/**
+ * Float DNA: Polynomial Mutation Method
+ * Perturbs each gene within the given mutation rate (probability) following the
+ * polynomial distribution of Deb & Goyal (2001), clamped to bounds. The larger
+ * the distribution index eta, the smaller the perturbations.
+ */
+func floatDNAPolynomialMutate(rng *PRNG, entity *FloatDNA, bounds [][2]float64, eta float64, rate float32) {
+	for i := range entity.genes {
+		if rng.Float32(0.0, 1.0) >= rate {
+			continue
+		}
+
+		var u = rng.Float64(0.0, 1.0)
+		var delta float64
+		if u < 0.5 {
+			delta = math.Pow(2*u, 1/(eta+1)) - 1
+		} else {
+			delta = 1 - math.Pow(2*(1-u), 1/(eta+1))
+		}
+
+		entity.genes[i] = clamp(entity.genes[i]+delta*(bounds[i][1]-bounds[i][0]), bounds[i])
+	}
+}
+
+/**
+ * Clamp
+ * Restricts x to the given [lower, upper] bound
+ */
+func clamp(x float64, bound [2]float64) float64 {
+	return math.Max(bound[0], math.Min(bound[1], x))
+}
+
+/**
+ * Float Population: Setup
+ * Fills the population with maxpop random entities and assesses their fitness
+ */
+func floatPopulationSetup(population *FloatPopulation, maxpop int) {
+	population.entities = []FloatDNA{}
+	for i := 0; i < maxpop; i++ {
+		var newDna = FloatDNA{}
+		dnaCreateFloat(population.rng, &newDna, population.bounds)
+		population.entities = append(population.entities, newDna)
+	}
+
+	floatPopulationCalculateFitness(population)
+}
+
+/**
+ * Float Population: Run a fitness assessment on every current member of the population
+ */
+func floatPopulationCalculateFitness(population *FloatPopulation) {
+	for i := range population.entities {
+		population.entities[i].fitness = population.fitnessFunc(&population.entities[i])
+	}
+}
+
+/**
+ * Float Population: Generation Iteration
+ * Replaces the population with children bred from binary tournament winners by
+ * BLX-alpha crossover and Gaussian mutation, keeping the best entity unchanged,
+ * then assesses the new generation's fitness
+ */
+func floatPopulationGenerate(population *FloatPopulation) {
+	var next = make([]FloatDNA, len(population.entities))
+	next[0] = population.entities[floatPopulationGetBest(population)]
+
+	for i := 1; i < len(next); i++ {
+		var partnerA = floatPopulationTournament(population)
+		var partnerB = floatPopulationTournament(population)
+		var child = dnaCrossoverBLX(population.rng, partnerA, partnerB, population.alpha, population.bounds)
+		dnaMutateGaussian(population.rng, &child, population.mutationRate, population.sigma, population.bounds)
+		next[i] = child
+	}
+
+	population.entities = next
+	population.generations++
+
+	floatPopulationCalculateFitness(population)
+}
+
+/**
+ * Float Population: Binary Tournament
+ * Returns the fitter of two randomly picked entities
+ */
+func floatPopulationTournament(population *FloatPopulation) *FloatDNA {
+	var a = &population.entities[population.rng.Int(0, len(population.entities))]
+	var b = &population.entities[population.rng.Int(0, len(population.entities))]
+	if b.fitness > a.fitness {
+		return b
+	}
+	return a
+}
+
+/**
+ * Float Population: Get Best
+ * Returns the index of the entity with the highest fitness
+ */
+func floatPopulationGetBest(population *FloatPopulation) int {
+	var index int
+	for i := range population.entities {
+		if population.entities[i].fitness > population.entities[index].fitness {
+			index = i
+		}
+	}
+	return index
+}
+
+/**
+ * Population: Run a fitness assessment on every current member of the population
+ * If the population has a custom fitness function it is used to assess each
+ * entity, rather than matching against the target.
+ * If the population has a dynamic target, the target for the current generation
+ * is used instead of the given target.
+ * In LaMarckian mode, each entity is improved by a hill climbing local search,
+ * which is written back to its genes.
+ * If the population has a fitness cache, genes already evaluated are looked up
+ * rather than assessed again.
+ * If the population has a constraint, entities violating it are given a
+ * fitness of 0 (the "death penalty").
+ * If the population has a penalty function, each entity's fitness is reduced
+ * by its weighted penalty (but never below 0).
+ */
+func populationCalculateFitness(population *Population, target string) {
+	if population.cfg.Evaluator != nil {
+		populationCalculateFitnessAsync(population, population.cfg.Evaluator, target)
+		return
+	}
+
+	population.CacheDirty = true
+
+	var assess = populationAssessor(population, target)
+	for i := 0; i < len(population.entities); i++ {
+		assess(&population.entities[i])
+	}
+
+	populationUpdateBest(population)
+}
+
+/**
+ * Population: Assessor
+ * Returns a function assessing the fitness of a single entity in the way the
+ * population's config asks, see populationCalculateFitness
+ */
+func populationAssessor(population *Population, target string) func(dna *DNA) {
+	var assess = populationCachedFitness(population, populationFitnessFunc(population, target))
+
+	var finish = populationFinisher(population, assess)
+	return func(dna *DNA) {
+		dna.fitness = assess(dna)
+		finish(dna)
+	}
+}
+
+/**
+ * Population: Fitness Function
+ * Returns the config's fitness function, or else one matching the genes
+ * against the target (for the current generation, if the target is dynamic)
+ */
+func populationFitnessFunc(population *Population, target string) FitnessFunc {
+	if population.cfg.FitnessFunc != nil {
+		return population.cfg.FitnessFunc
+	}
+
+	if population.cfg.DynamicTargetFn != nil {
+		target = population.cfg.DynamicTargetFn(population.generations)
+	}
+
+	return func(dna *DNA) float32 {
+		// An entity whose genes don't line up with the target is simply unfit
+		dnaAssessFitness(dna, target)
+		return dna.fitness
+	}
+}
+
+/**
+ * Population: Cached Fitness
+ * Returns the fitness function looking up and caching its results in the
+ * population's fitness cache, if it has one
+ */
+func populationCachedFitness(population *Population, fitness FitnessFunc) FitnessFunc {
+	var cache = population.cfg.FitnessCache
+	if cache == nil {
+		return fitness
+	}
+
+	return func(dna *DNA) float32 {
+		return cache.Evaluate(dna, fitness)
+	}
+}
+
+/**
+ * Population: Finisher
+ * Returns a function adjusting the fitness an entity has been assessed with
+ * in the way the population's config asks: improving it by local search in
+ * LaMarckian mode (with assess), then applying any constraint, penalty and
+ * aging penalty. See populationCalculateFitness.
+ */
+func populationFinisher(population *Population, assess FitnessFunc) func(dna *DNA) {
+	var penaltyWeight = population.cfg.PenaltyWeight
+	if population.cfg.PenaltySchedule != nil {
+		penaltyWeight = population.cfg.PenaltySchedule(population.generations)
+	}
+
+	return func(dna *DNA) {
+		if population.cfg.LaMarckianMode {
+			var steps = population.cfg.LocalSearchSteps
+			if steps <= 0 {
+				steps = 10
+			}
+
+			var improved, fitness = HillClimbLocalSearch(population.rng, dna, assess, steps, population.cfg.Alphabet)
+			if fitness > dna.fitness {
+				LaMarckianUpdate(dna, improved)
+				dna.fitness = fitness
+			}
+		}
+
+		if population.cfg.ConstraintFn != nil && !population.cfg.ConstraintFn(dna) {
+			dna.fitness = 0
+		}
+
+		if population.cfg.PenaltyFn != nil {
+			var penalty = penaltyWeight * population.cfg.PenaltyFn(dna)
+			dna.fitness = float32(math.Max(0, float64(dna.fitness-penalty)))
+		}
+
+		// Old entities fade, reaching 0 fitness at an age of 1 / AgingPenalty
+		if population.cfg.AgingPenalty > 0 {
+			var vitality = 1 - float32(dna.Age)*population.cfg.AgingPenalty
+			dna.fitness *= float32(math.Max(0, float64(vitality)))
+		}
+	}
+}
+
+/**
+ * Fitness Cache: Evaluate
+ * Returns the cached fitness of the dna's genes, evaluating and caching it
+ * with fn if they have not been seen before
+ */
+func (c *FitnessCache) Evaluate(dna *DNA, fn FitnessFunc) float32 {
+	var key = dnaExtractPhrase(dna)
+
+	c.mu.Lock()
+	if fitness, ok := c.cache[key]; ok {
+		c.hits++
+		c.mu.Unlock()
+		return fitness
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	var fitness = fn(dna)
+
+	c.mu.Lock()
+	if c.cache == nil {
+		c.cache = make(map[string]float32)
+	}
+	c.cache[key] = fitness
+	c.mu.Unlock()
+
+	return fitness
+}
+
+/**
+ * Fitness Cache: Hit Rate
+ * Returns the fraction of evaluations answered from the cache
+ */
+func (c *FitnessCache) CacheHitRate() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.hits+c.misses == 0 {
+		return 0
+	}
+	return float64(c.hits) / float64(c.hits+c.misses)
+}
+
+/**
+ * New Async Evaluator
+ * Creates an evaluator running the fitness function on the given number of
+ * workers (at least 1). Without a fitness function, populations using the
+ * evaluator are assessed as their config asks, only concurrently.
+ */
+func NewAsyncEvaluator(workers int, fitness FitnessFunc) *AsyncEvaluator {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &AsyncEvaluator{workers: workers, fitness: fitness}
+}
+
+/**
+ * Async Evaluator: Start
+ * Starts the workers, which wait for dna to evaluate until the evaluator is
+ * stopped. Starting a running evaluator does nothing.
+ */
+func (e *AsyncEvaluator) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.start()
+}
+
+/**
+ * Async Evaluator: Start (Locked)
+ * Starts the workers unless they are running; the caller holds e.mu
+ */
+func (e *AsyncEvaluator) start() {
+	if e.jobs != nil {
+		return
+	}
+
+	e.jobs = make(chan evalJob, e.workers)
+
+	for i := 0; i < e.workers; i++ {
+		e.wg.Add(1)
+		go func(jobs <-chan evalJob) {
+			defer e.wg.Done()
+			for job := range jobs {
+				job.result <- job.fitness(job.dna)
+			}
+		}(e.jobs)
+	}
+}
+
+/**
+ * Async Evaluator: Evaluate
+ * Queues the dna for evaluation, returning a channel which receives its fitness
+ * once evaluated. The dna must not change until then. The workers are started
+ * if they are not running, whether never started or since stopped.
+ */
+func (e *AsyncEvaluator) Evaluate(dna *DNA) <-chan float32 {
+	return e.evaluate(dna, e.fitness)
+}
+
+/**
+ * Async Evaluator: Evaluate With
+ * Queues the dna for evaluation with the given fitness function, see Evaluate
+ */
+func (e *AsyncEvaluator) evaluate(dna *DNA, fitness FitnessFunc) <-chan float32 {
+	var result = make(chan float32, 1)
+
+	// Held while queueing, so the evaluator cannot be stopped in between
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.start()
+	e.jobs <- evalJob{dna, fitness, result}
+
+	return result
+}
+
+/**
+ * Async Evaluator: Stop
+ * Stops the workers once every queued dna has been evaluated. Stopping a
+ * stopped evaluator does nothing.
+ */
+func (e *AsyncEvaluator) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.jobs == nil {
+		return
+	}
+
+	close(e.jobs)
+	e.jobs = nil
+	e.wg.Wait()
+}
+
+/**
+ * Population: Calculate Fitness Asynchronously
+ * Submits every entity to the evaluator, then waits for all of their fitness.
+ * The evaluator's fitness function (if it has one) replaces the config's
+ * FitnessFunc or target matching, but is otherwise treated the same: results
+ * are cached, and then adjusted by local search, constraint and penalties,
+ * as in populationCalculateFitness.
+ */
+func populationCalculateFitnessAsync(population *Population, evaluator *AsyncEvaluator, target string) {
+	population.CacheDirty = true
+
+	var fitness = evaluator.fitness
+	if fitness == nil {
+		fitness = populationFitnessFunc(population, target)
+	}
+
+	var assess = populationCachedFitness(population, fitness)
+	var finish = populationFinisher(population, assess)
+
+	var results = make([]<-chan float32, len(population.entities))
+	for i := range population.entities {
+		results[i] = evaluator.evaluate(&population.entities[i], assess)
+	}
+
+	// Local search and penalties run here, as they need not be safe for concurrent use
+	for i, result := range results {
+		population.entities[i].fitness = <-result
+		finish(&population.entities[i])
+	}
+
+	populationUpdateBest(population)
+}
+
+/**
+ * Novelty Archive: Add
+ * Archives a behaviour, pruning the archive if it is then over capacity
+ */
+func (a *NoveltyArchive) Add(behavior []float64) {
+	a.Behaviors = append(a.Behaviors, behavior)
+
+	if a.Capacity > 0 && len(a.Behaviors) > a.Capacity {
+		a.Prune(a.Capacity)
+	}
+}
+
+/**
+ * Novelty Archive: Novelty
+ * Scores how novel a behaviour is, as its mean distance to its k nearest
+ * archived behaviours. An empty archive finds everything infinitely novel.
+ */
+func (a *NoveltyArchive) Novelty(behavior []float64) float64 {
+	var distances = make([]float64, len(a.Behaviors))
+	for i, other := range a.Behaviors {
+		distances[i] = behaviorDistance(behavior, other)
+	}
+	sort.Float64s(distances)
+
+	var k = a.neighbours(len(distances))
+	if k == 0 {
+		return math.Inf(1)
+	}
+
+	var total float64
+	for _, d := range distances[:k] {
+		total += d
+	}
+	return total / float64(k)
+}
+
+/**
+ * Novelty Archive: Prune
+ * Removes the most crowded behaviours, one at a time, until at most maxSize
+ * remain. The most crowded is that with the highest sum of inverse distances to
+ * its k nearest neighbours.
+ */
+func (a *NoveltyArchive) Prune(maxSize int) {
+	if maxSize < 0 {
+		maxSize = 0
+	}
+
+	for len(a.Behaviors) > maxSize {
+		var crowded, highest = 0, math.Inf(-1)
+
+		for i := range a.Behaviors {
+			var distances = make([]float64, 0, len(a.Behaviors)-1)
+			for j := range a.Behaviors {
+				if i != j {
+					distances = append(distances, behaviorDistance(a.Behaviors[i], a.Behaviors[j]))
+				}
+			}
+			sort.Float64s(distances)
+
+			var density float64
+			for _, d := range distances[:a.neighbours(len(distances))] {
+				density += 1 / d // Identical behaviours are infinitely crowded
+			}
+
+			if density > highest {
+				crowded, highest = i, density
+			}
+		}
+
+		a.Behaviors = append(a.Behaviors[:crowded], a.Behaviors[crowded+1:]...)
+	}
+}
+
+/**
+ * Novelty Archive: Neighbours
+ * Returns the number of nearest neighbours to consider out of those available
+ */
+func (a *NoveltyArchive) neighbours(available int) int {
+	var k = a.K
+	if k <= 0 {
+		k = 15
+	}
+	if k > available {
+		k = available
+	}
+	return k
+}
+
+/**
+ * Behaviour Distance
+ * Returns the Euclidean distance between two behaviours
+ */
+func behaviorDistance(a, b []float64) float64 {
+	var sum float64
+	for i := 0; i < len(a) && i < len(b); i++ {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+/**
+ * Hill Climb Local Search
+ * Tries steps random single gene changes on a copy of the dna, keeping those
+ * which improve its fitness, and returns the improved phrase and its fitness
+ */
+func HillClimbLocalSearch(rng *PRNG, dna *DNA, assess FitnessFunc, steps int, alphabet Alphabet) (string, float32) {
+	var candidate = dna.Clone()
+	var best = assess(&candidate)
+
+	for step := 0; step < steps && len(candidate.genes) > 0; step++ {
+		var i = rng.Int(0, len(candidate.genes))
+		var previous = candidate.genes[i]
+
+		candidate.genes[i] = alphabet.Random(rng)
+		if fitness := assess(&candidate); fitness > best {
+			best = fitness
+		} else {
+			candidate.genes[i] = previous
+		}
+	}
+
+	return dnaExtractPhrase(&candidate), best
+}
+
+/**
+ * LaMarckian Update
+ * Writes the phrase found by local search back into the child's genes, so that
+ * the improvement is inherited by its own children
+ */
+func LaMarckianUpdate(child *DNA, improvedPhrase string) {
+	child.genes = []rune(improvedPhrase)
+}
+
+/**
+ * Linear Penalty Schedule
+ * Returns a penalty schedule starting at the given weight, and increasing by
+ * step every generation
+ */
+func LinearPenaltySchedule(start, step float32) PenaltySchedule {
+	return func(generation int) float32 {
+		return start + step*float32(generation)
+	}
+}
+
+/**
+ * Population: Infeasible Count
+ * Counts the entities of the current population which violate the population's
+ * constraint (and so have been given zero fitness)
+ */
+func InfeasibleCount(population *Population) int {
+	if population.cfg.ConstraintFn == nil {
+		return 0
+	}
+
+	var count int
+	for i := 0; i < len(population.entities); i++ {
+		if !population.cfg.ConstraintFn(&population.entities[i]) {
+			count++
+		}
+	}
+
+	return count
+}
+
+/**
+ * Cyclic Target
+ * Returns a dynamic target function which cycles through the given targets,
+ * moving on to the next target every period generations
+ */
+func CyclicTarget(targets []string, period int) DynamicTargetFn {
+	if period < 1 {
+		period = 1
+	}
+
+	return func(generation int) string {
+		return targets[(generation/period)%len(targets)]
+	}
+}
+
+/**
+ * Generation Improvement Adaptor: Adapt Mutation Rate
+ * Implements MutationAdaptor, adapting to the population's average fitness
+ */
+func (a *GenerationImprovementAdaptor) AdaptMutationRate(population *Population) float32 {
+	if a.Rate == 0 {
+		a.Rate = population.cfg.MutationRate
+	}
+
+	return a.Adapt(populationAverageFitness(population))
+}
+
+/**
+ * Generation Improvement Adaptor: Reset
+ * Implements ResettableMutationAdaptor, forgetting the fitness history
+ */
+func (a *GenerationImprovementAdaptor) Reset(rate float32) {
+	a.History = nil
+	a.Rate = rate
+}
+
+/**
+ * Hypermutation Trigger: Reset
+ * Implements ResettableMutationAdaptor, forgetting the fitness history
+ */
+func (t *HypermutationTrigger) Reset(rate float32) {
+	t.history = nil
+	t.baseline = rate
+}
+
+/**
+ * Hypermutation Trigger: Adapt Mutation Rate
+ * Implements MutationAdaptor, counting down an ongoing hypermutation or
+ * triggering one if the population's best fitness has stagnated
+ */
+func (t *HypermutationTrigger) AdaptMutationRate(population *Population) float32 {
+	if population.hypermutationCountdown > 0 {
+		population.hypermutationCountdown--
+		if population.hypermutationCountdown > 0 {
+			return t.HighRate
+		}
+		return t.baseline
+	}
+
+	t.baseline = population.cfg.MutationRate
+
+	if population.CacheDirty {
+		populationUpdateBest(population)
+	}
+	t.history = append(t.history, population.bestFitness)
+	if len(t.history) > t.StagnationWindow+1 {
+		t.history = t.history[len(t.history)-(t.StagnationWindow+1):]
+	}
+
+	var stagnated = t.StagnationWindow >= 1 && len(t.history) > t.StagnationWindow &&
+		t.history[len(t.history)-1] <= t.history[0]
+	if stagnated && t.Duration > 0 {
+		population.hypermutationCountdown = t.Duration
+		t.history = t.history[:0]
+		return t.HighRate
+	}
+
+	return t.baseline
+}
+
+/**
+ * Generation Improvement Adaptor: Adapt
+ * Records the current generation's average fitness and returns the adapted
+ * mutation rate. The rate is left unchanged until Window generations of
+ * history have been recorded.
+ */
+func (a *GenerationImprovementAdaptor) Adapt(currentAvg float32) float32 {
+	a.History = append(a.History, currentAvg)
+
+	// Only keep enough history to measure improvement over the window
+	if len(a.History) > a.Window+1 {
+		a.History = a.History[len(a.History)-(a.Window+1):]
+	}
+
+	if a.Window < 1 || len(a.History) <= a.Window {
+		return a.Rate
+	}
+
+	var improvement = a.History[len(a.History)-1] - a.History[0]
+	if improvement < a.Threshold {
+		a.Rate *= a.BoostFactor
+	} else {
+		a.Rate *= a.DecayFactor
+	}
+
+	if a.Rate > 1.0 {
+		a.Rate = 1.0
+	}
+	if a.Rate < minAdaptedMutationRate {
+		a.Rate = minAdaptedMutationRate
+	}
+
+	return a.Rate
+}
+
+/**
+ * Population: Mating Pool Generator
+ * Performs Natural Selection on the current generation of entities, and creates
+ * a mating pool of DNA candidates to become parents.
+ */
+func populationNaturalSelection(population *Population) {
+	// Reset the mating pool first, allocating for the largest possible pool up front
+	// (every entity at the maximum fitness multiplier)
+	population.matingPool = make([]DNA, 0, len(population.entities)*100)
+
+	var maxFitness float32
+
+	// Find the fittest entity in the current population
+	for i := 0; i < len(population.entities); i++ {
+		if population.entities[i].fitness > maxFitness {
+			maxFitness = population.entities[i].fitness
+		}
+	}
+
+	// With no fitness to go on (every entity scored 0) the fitness can't be mapped,
+	// so every entity is given an equal chance with a single entry
+	if maxFitness == 0 {
+		population.matingPool = append(population.matingPool, population.entities...)
+		return
+	}
+
+	// Each member of the current population will be added to the new mating pool a given number of times
+	// based on their assessed fitnes. The higher the fitness, the more entries a single entity will have
+	// therefore increasing the chances of a fitter child being produced (Natural Selection)
+	for i := 0; i < len(population.entities); i++ {
+		var fitness = highLowMap(population.entities[i].fitness, 0, maxFitness, 0, 1)
+		var n = int(fitness * 100) // Like the book we use an Arbitrary multiplier. An alternative would be the monte carlo method.
+		for j := 0; j < n; j++ {   // We pick out two random numbers
+			population.matingPool = append(population.matingPool, population.entities[i])
+		}
+	}
+}
+
+/**
+ * Selector Func: Select
+ */
+func (f SelectorFunc) Select(population *Population) {
+	f(population)
+}
+
+/**
+ * Fitness Proportionate Selector: Select
+ */
+func (s FitnessProportionateSelector) Select(population *Population) {
+	populationNaturalSelection(population)
+}
+
+/**
+ * Tournament Selector: Select
+ * Runs one tournament per entity in the population, adding each winner to the
+ * mating pool
+ */
+func (s *TournamentSelector) Select(population *Population) {
+	population.matingPool = []DNA{}
+
+	for i := 0; i < len(population.entities); i++ {
+		population.matingPool = append(population.matingPool, *s.tournament(population))
+	}
+}
+
+/**
+ * Tournament Selector: Tournament
+ * Holds a single tournament between Size picked entities, returning the winner
+ */
+func (s *TournamentSelector) tournament(population *Population) *DNA {
+	var size = s.Size
+	if size < 1 {
+		size = 1
+	}
+
+	var candidates []int
+	if s.WithReplacement {
+		candidates = make([]int, size)
+		for j := range candidates {
+			candidates[j] = population.rng.Int(0, len(population.entities))
+		}
+	} else {
+		if size > len(population.entities) {
+			size = len(population.entities)
+		}
+		candidates = population.rng.Perm(len(population.entities))[:size]
+	}
+
+	var winner = &population.entities[candidates[0]]
+	for _, j := range candidates[1:] {
+		if s.beats(&population.entities[j], winner) {
+			winner = &population.entities[j]
+		}
+	}
+
+	return winner
+}
+
+/**
+ * Tournament Selector: Beats
+ * Reports whether entity a wins a tournament round against entity b
+ */
+func (s *TournamentSelector) beats(a, b *DNA) bool {
+	if s.Better != nil {
+		return s.Better(a, b)
+	}
+	return a.fitness > b.fitness
+}
+
+/**
+ * Linear Rank Selector: Select
+ * Ranks the entities from worst to best, then samples as many entries for the
+ * mating pool as there are entities, at evenly spaced points across their rank
+ * probabilities
+ */
+func (s *LinearRankSelector) Select(population *Population) {
+	var order = populationWorstOrder(population)
+	var probabilities = linearRankProbabilities(len(order), s.SP)
+
+	population.matingPool = make([]DNA, 0, len(order))
+	if len(order) == 0 {
+		return
+	}
+
+	var spacing = 1.0 / float64(len(order))
+	var pointer = population.rng.Float64(0, spacing)
+	var cumulative float64
+	for rank, i := range order {
+		cumulative += probabilities[rank]
+		for pointer < cumulative && len(population.matingPool) < len(order) {
+			population.matingPool = append(population.matingPool, population.entities[i])
+			pointer += spacing
+		}
+	}
+
+	// Rounding can leave the probabilities a little short of 1, missing the last pointer
+	for len(population.matingPool) < len(order) {
+		population.matingPool = append(population.matingPool, population.entities[order[len(order)-1]])
+	}
+}
+
+/**
+ * Linear Rank Probabilities
+ * Returns the selection probability of each of n ranks, from the worst (rank 0)
+ * to the best, under selective pressure sp:
+ * P(i) = (2 - sp)/n + 2i(sp - 1)/(n(n - 1))
+ */
+func linearRankProbabilities(n int, sp float64) []float64 {
+	var probabilities = make([]float64, n)
+	if n == 1 {
+		probabilities[0] = 1
+		return probabilities
+	}
+
+	for i := range probabilities {
+		probabilities[i] = (2-sp)/float64(n) + 2*float64(i)*(sp-1)/float64(n*(n-1))
+	}
+
+	return probabilities
+}
+
+/**
+ * Feasibility Rule Selection
+ * Returns a binary tournament selector applying Deb's (2000) constraint handling
+ * rules: a feasible entity beats an infeasible one, two feasible entities are
+ * compared by fitness, and two infeasible entities by their degree of violation
+ * (the lower the better).
+ */
+func FeasibilityRuleSelection(constraintFn func(*DNA) bool, violationFn func(*DNA) float32) Selector {
+	return &TournamentSelector{
+		Size: 2,
+		Better: func(a, b *DNA) bool {
+			var feasibleA, feasibleB = constraintFn(a), constraintFn(b)
+
+			switch {
+			case feasibleA && !feasibleB:
+				return true
+			case !feasibleA && feasibleB:
+				return false
+			case feasibleA && feasibleB:
+				return a.fitness > b.fitness
+			default:
+				return violationFn(a) < violationFn(b)
+			}
+		},
+	}
+}
+
+/**
+ * Population: Generation Iteration
+ * Replaces the population's entities with the new entities generated
+ * from the mating pool, performing DNA crossover and mutation.
+ * When the generational gap is below 1.0, only that fraction of the population
+ * (the worst entities) is replaced and the rest survive.
+ * The fittest EliteCount entities (the elite) always survive unchanged.
+ */
+func populationGenerate(population *Population) error {
+	if len(population.matingPool) == 0 {
+		return ErrEmptyMatingPool{}
+	}
+
+	var slots []int
+	var gap = population.cfg.GenerationalGap
+	var n = int(gap * float32(len(population.entities)))
+	var elite = population.cfg.EliteCount
+
+	// However small the population, a gap replaces at least one entity
+	if gap > 0 && n < 1 {
+		n = 1
+	}
+
+	if (gap <= 0 || n >= len(population.entities)) && elite <= 0 {
+		// Full replacement
+		slots = make([]int, len(population.entities))
+		for i := range slots {
+			slots[i] = i
+		}
+	} else {
+		// Replace only the worst n entities, sparing the elite
+		var limit = len(population.entities) - elite
+		if gap > 0 && n < limit {
+			limit = n
+		}
+		if limit < 0 {
+			limit = 0
+		}
+		slots = populationWorstOrder(population)[:limit]
+	}
+
+	population.CacheDirty = true
+
+	if population.pool == nil {
+		population.pool = &DNAPool{geneLen: len([]rune(population.cfg.Target))}
+	}
+
+	// Every entity ages a generation, the children replacing some start afresh
+	for i := range population.entities {
+		population.entities[i].Age++
+	}
+
+	// Refill the population with children from the mating pool, each child is
+	// borrowed from the pool and the entity it replaces is retired
+	var retired = make([]*DNA, 0, len(slots))
+	for _, i := range slots {
+		var audited int
+		if population.Audit != nil {
+			audited = population.Audit.count()
+		}
+
+		var child = population.pool.Get()
+		if err := populationBreedInto(population, child); err != nil {
+			population.pool.Put(child)
+			return err
+		}
+		population.entities[i], *child = *child, population.entities[i]
+		retired = append(retired, child)
+
+		if population.Audit != nil {
+			population.Audit.assignEntity(audited, i)
+		}
+	}
+
+	// The mating pool shares genes with the retired entities, so they can only
+	// be recycled once the whole generation has been bred
+	for _, d := range retired {
+		population.pool.Put(d)
+	}
+
+	population.generations++
+
+	return nil
+}
+
+/**
+ * Population: Breed
+ * Picks two parents from the mating pool and returns their mutated child.
+ * Crossover is only performed with the configured crossover rate (probability),
+ * and only if the first parent is in crossover mode, otherwise the child is a
+ * copy of the first parent.
+ * The child inherits the first parent's reproduction mode, which flips with the
+ * configured mode mutation rate (probability).
+ */
+func populationBreed(population *Population) (DNA, error) {
+	var child DNA
+	var err = populationBreedInto(population, &child)
+
+	return child, err
+}
+
+/**
+ * Population: Breed Into
+ * As populationBreed, but writes the child into the given dna, reusing its gene
+ * slice where it is large enough
+ */
+func populationBreedInto(population *Population, child *DNA) error {
+	if len(population.matingPool) == 0 {
+		return ErrEmptyMatingPool{}
+	}
+
+	var a, b = populationPickParents(population)
+
+	var partnerA, partnerB DNA
+	partnerA = population.matingPool[a]
+	partnerB = population.matingPool[b]
+
+	// With sexual selection, partner A chooses a sufficiently different mate
+	if population.cfg.Choosiness > 0 {
+		partnerB = populationChooseMate(population, &partnerA, population.cfg.Choosiness)
+	}
+
+	if partnerA.ReproductionMode == CrossoverMode && population.rng.Float32(0.0, 1.0) < population.cfg.CrossoverRate {
+		if err := dnaCrossoverInto(population.rng, child, &partnerA, &partnerB); err != nil {
+			return err
+		}
+		if population.cfg.RepairFn != nil {
+			*child = *population.cfg.RepairFn(child)
+		}
+		child.ParentIDs = [2]int64{partnerA.ID, partnerB.ID}
+	} else {
+		dnaCopyGenes(child, &partnerA)
+		child.fitness = 0
+		child.ParentIDs = [2]int64{partnerA.ID, 0}
+	}
+	child.ID = dnaNewID()
+
+	// The child is bred into the next generation
+	var record func(pos int, oldRune, newRune rune)
+	if population.Audit != nil {
+		record = population.Audit.recorder(population.generations + 1)
+	}
+	dnaMutateRecording(population.rng, child, population.cfg.MutationRate, population.cfg.Alphabet, record)
+
+	child.ReproductionMode = partnerA.ReproductionMode
+	if population.rng.Float32(0.0, 1.0) < population.cfg.ModeMutationRate {
+		if child.ReproductionMode == CrossoverMode {
+			child.ReproductionMode = ClonalMode
+		} else {
+			child.ReproductionMode = CrossoverMode
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Population: Pick Parents
+ * Picks the mating pool indexes of two parents. The second is drawn from the
+ * other indexes, wrapping around the pool, so that no entry is crossed with
+ * itself unless the pool holds only one.
+ */
+func populationPickParents(population *Population) (a, b int) {
+	var size = len(population.matingPool)
+
+	a = population.rng.Int(0, size)
+	if size < 2 {
+		return a, a
+	}
+	b = (a + population.rng.Int(1, size)) % size
+
+	return a, b
+}
+
+/**
+ * Sexual Selection Crossover
+ * Picks a chooser from the mating pool, which then chooses a mate (see
+ * populationChooseMate) to cross over with, returning their child
+ */
+func SexualSelectionCrossover(population *Population, choosiness float32) (DNA, error) {
+	if len(population.matingPool) == 0 {
+		return DNA{}, ErrEmptyMatingPool{}
+	}
+
+	var chooser = population.matingPool[population.rng.Int(0, len(population.matingPool))]
+	var partner = populationChooseMate(population, &chooser, choosiness)
+
+	return dnaCrossover(population.rng, &chooser, &partner)
+}
+
+/**
+ * Population: Choose Mate
+ * Samples partners from the mating pool for the chooser, rejecting those whose
+ * distance from the chooser is below choosiness * gene length. After 10
+ * rejections the most different partner sampled is accepted.
+ */
+func populationChooseMate(population *Population, chooser *DNA, choosiness float32) DNA {
+	const maxAttempts = 10
+
+	var threshold = int(choosiness * float32(len(chooser.genes)))
+	var best DNA
+	var bestDistance = -1
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var partner = population.matingPool[population.rng.Int(0, len(population.matingPool))]
+		var distance = HammingDistance(chooser, &partner)
+		if distance >= threshold {
+			return partner
+		}
+		if distance > bestDistance {
+			best, bestDistance = partner, distance
+		}
+	}
+
+	return best
+}
+
+/**
+ * DNA Pool: Get
+ * Borrows a DNA from the pool, allocating a new one when the pool is empty
+ */
+func (p *DNAPool) Get() *DNA {
+	if d, ok := p.pool.Get().(*DNA); ok {
+		*d = DNA{genes: d.genes}
+		return d
+	}
+	return &DNA{genes: make([]rune, p.geneLen)}
+}
+
+/**
+ * DNA Pool: Put
+ * Returns a DNA to the pool for reuse. Nothing else may hold its genes.
+ */
+func (p *DNAPool) Put(d *DNA) {
+	p.pool.Put(d)
+}
+
+/**
+ * Population: Steady-State Generation Iteration
+ * Breeds a single child from two parents in the mating pool, and replaces the
+ * given number of worst (lowest fitness) entities with copies of it. The rest
+ * of the population survives into the next generation unchanged.
+ */
+func SteadyStateGenerate(population *Population, replacements int) error {
+	var child, err = populationBreed(population)
+	if err != nil {
+		return err
+	}
+
+	var order = populationWorstOrder(population)
+
+	if replacements > len(order) {
+		replacements = len(order)
+	}
+
+	population.CacheDirty = true
+
+	for i := range population.entities {
+		population.entities[i].Age++
+	}
+
+	// Each replaced entity receives its own copy of the child's genes
+	for i := 0; i < replacements; i++ {
+		population.entities[order[i]] = child.Clone()
+	}
+
+	population.generations++
+
+	return nil
+}
+
+/**
+ * Tournament Replacement
+ * Breeds a child from the winners of two tournaments between tournamentSize
+ * entities (at least 2), then holds a third tournament to find a loser. The
+ * child replaces the loser only if it is fitter, so no entity is ever replaced
+ * by a worse one.
+ */
+func TournamentReplacement(population *Population, tournamentSize int) error {
+	if tournamentSize < 2 {
+		tournamentSize = 2
+	}
+
+	var winners = TournamentSelector{Size: tournamentSize}
+	var losers = TournamentSelector{Size: tournamentSize, Better: func(a, b *DNA) bool {
+		return a.fitness < b.fitness
+	}}
+
+	// The tournament winners make up the whole mating pool, so become the parents
+	population.matingPool = []DNA{*winners.tournament(population), *winners.tournament(population)}
+
+	var child, err = populationBreed(population)
+	if err != nil {
+		return err
+	}
+	populationAssessor(population, population.cfg.Target)(&child)
+
+	if loser := losers.tournament(population); child.fitness > loser.fitness {
+		*loser = child
+		population.CacheDirty = true
+	}
+
+	return nil
+}
+
+/**
+ * (μ + λ) Generation Iteration
+ * Breeds lambda children from the mating pool and pools them with the current
+ * entities (the μ parents). The fittest μ of the combined pool survive into the
+ * next generation, so the best entity is never lost. Ties favour the parents.
+ */
+func MuPlusLambdaGenerate(population *Population, lambda int) error {
+	var mu = len(population.entities)
+	var assess = populationAssessor(population, population.cfg.Target)
+
+	var pool = make([]DNA, mu, mu+lambda)
+	copy(pool, population.entities)
+	for i := range pool {
+		pool[i].Age++
+	}
+
+	for i := 0; i < lambda; i++ {
+		var child, err = populationBreed(population)
+		if err != nil {
+			return err
+		}
+		assess(&child)
+		pool = append(pool, child)
+	}
+
+	sort.SliceStable(pool, func(i, j int) bool {
+		return pool[i].fitness > pool[j].fitness
+	})
+
+	population.entities = pool[:mu]
+	populationUpdateBest(population)
+	population.generations++
+
+	return nil
+}
+
+/**
+ * (μ, λ) Generation Iteration
+ * Breeds lambda children from the mu fittest entities, and replaces the whole
+ * population with the fittest mu of the children. Every parent is discarded,
+ * so old solutions can't hold the population on a local optimum.
+ * mu is clamped to the size of the population, and lambda raised to mu.
+ */
+func MuCommaLambdaGenerate(population *Population, mu, lambda int) error {
+	if mu > len(population.entities) {
+		mu = len(population.entities)
+	}
+	if lambda < mu {
+		lambda = mu
+	}
+
+	var assess = populationAssessor(population, population.cfg.Target)
+
+	// The mu parents make up the whole mating pool
+	population.matingPool = PopulationTopK(population, mu)
+
+	var children = make([]DNA, 0, lambda)
+	for i := 0; i < lambda; i++ {
+		var child, err = populationBreed(population)
+		if err != nil {
+			return err
+		}
+		assess(&child)
+		children = append(children, child)
+	}
+
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].fitness > children[j].fitness
+	})
+
+	population.entities = children[:mu]
+	populationUpdateBest(population)
+	population.generations++
+
+	return nil
+}
+
+/**
+ * Hamming Distance
+ * Counts the gene positions at which the two dna differ
+ * Returns -1 if the dna have different gene lengths
+ */
+func HammingDistance(a, b *DNA) int {
+	if len(a.genes) != len(b.genes) {
+		return -1
+	}
+
+	var distance int
+	for i := range a.genes {
+		if a.genes[i] != b.genes[i] {
+			distance++
+		}
+	}
+
+	return distance
+}
+
+/**
+ * DNA Diff
+ * Returns the gene positions at which the two dna differ, in order
+ * Returns ErrGeneLengthMismatch if the dna have different gene lengths
+ */
+func DNADiff(a, b *DNA) ([]int, error) {
+	if len(a.genes) != len(b.genes) {
+		return nil, ErrGeneLengthMismatch{len(a.genes), len(b.genes)}
+	}
+
+	var positions = []int{}
+	for i := range a.genes {
+		if a.genes[i] != b.genes[i] {
+			positions = append(positions, i)
+		}
+	}
+
+	return positions, nil
+}
+
+/**
+ * DNA Diff String
+ * Describes each gene position at which the two dna differ, one per line, as
+ * in "pos 3: 'x' vs 'y'"
+ */
+func DNADiffString(a, b *DNA) string {
+	var positions, err = DNADiff(a, b)
+	if err != nil {
+		return err.Error()
+	}
+
+	var lines = make([]string, len(positions))
+	for i, pos := range positions {
+		lines[i] = fmt.Sprintf("pos %d: %q vs %q", pos, a.genes[pos], b.genes[pos])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/**
+ * Apply Fitness Sharing
+ * Divides each entity's fitness by its niche count, the sum of its sharing with
+ * every entity (itself included), so that crowded entities are less fit. Sharing
+ * falls linearly from 1 at distance 0 to 0 at distance sigma and beyond.
+ */
+func ApplyFitnessSharing(population *Population, sigma float32) {
+	if sigma <= 0 {
+		return
+	}
+
+	populationShareFitness(population, func(d float64) float64 {
+		return math.Max(0, 1-d/float64(sigma))
+	})
+}
+
+/**
+ * Apply Boltzmann Fitness Sharing
+ * Fitness sharing (see ApplyFitnessSharing) with a soft kernel, the sharing
+ * between entities at distance d being exp(-d²/(2σ²))
+ */
+func ApplyBoltzmannFitnessSharing(population *Population, sigma float32) {
+	if sigma <= 0 {
+		return
+	}
+
+	var variance = float64(sigma) * float64(sigma)
+	populationShareFitness(population, func(d float64) float64 {
+		return math.Exp(-d * d / (2 * variance))
+	})
+}
+
+/**
+ * Population: Share Fitness
+ * Divides each entity's fitness by its niche count under the given sharing
+ * function of Hamming distance. Entities of different lengths share nothing.
+ */
+func populationShareFitness(population *Population, share func(d float64) float64) {
+	var niches = make([]float64, len(population.entities))
+	for i := range population.entities {
+		for j := range population.entities {
+			if distance := HammingDistance(&population.entities[i], &population.entities[j]); distance >= 0 {
+				niches[i] += share(float64(distance))
+			}
+		}
+	}
+
+	for i := range population.entities {
+		if niches[i] > 0 {
+			population.entities[i].fitness = float32(float64(population.entities[i].fitness) / niches[i])
+		}
+	}
+
+	population.CacheDirty = true
+}
+
+/**
+ * Population: Speciate
+ * Groups the current entities into species, returning the entity indexes of
+ * each species. An entity joins the first species whose founding member is
+ * within the configured species distance of it, or founds a new species.
+ */
+func populationSpeciate(population *Population) [][]int {
+	var species [][]int
+
+	for i := range population.entities {
+		var joined bool
+		for s := range species {
+			var distance = HammingDistance(&population.entities[i], &population.entities[species[s][0]])
+			if distance >= 0 && distance <= population.cfg.SpeciesDistance {
+				species[s] = append(species[s], i)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			species = append(species, []int{i})
+		}
+	}
+
+	return species
+}
+
+/**
+ * Dynamic Population Sizing
+ * Resizes the population so that each species has at least targetSpeciesSize
+ * members, without exceeding the configured maximum population. Room is made by
+ * removing the worst members of over-represented species (never taking them
+ * below the target size), then under-sized species are grown with children of
+ * intra-species crossover.
+ */
+func DynamicPopulationSizing(population *Population, targetSpeciesSize int) {
+	var species = populationSpeciate(population)
+
+	var needed int
+	for _, members := range species {
+		if len(members) < targetSpeciesSize {
+			needed += targetSpeciesSize - len(members)
+		}
+	}
+
+	// Make room by removing the worst members of the largest species first
+	var excess = len(population.entities) + needed - population.cfg.MaxPop
+	var removed = make(map[int]bool)
+	for excess > 0 {
+		var largest = -1
+		for s, members := range species {
+			if len(members) > targetSpeciesSize && (largest < 0 || len(members) > len(species[largest])) {
+				largest = s
+			}
+		}
+		if largest < 0 {
+			break
+		}
+
+		var members = species[largest]
+		var worst = 0
+		for m := range members {
+			if population.entities[members[m]].fitness < population.entities[members[worst]].fitness {
+				worst = m
+			}
+		}
+		removed[members[worst]] = true
+		species[largest] = append(members[:worst:worst], members[worst+1:]...)
+		excess--
+	}
+
+	// Grow the under-sized species with children of their own members
+	var children []DNA
+	var room = population.cfg.MaxPop - (len(population.entities) - len(removed))
+	for _, members := range species {
+		for n := len(members); n < targetSpeciesSize && len(children) < room; n++ {
+			var partnerA = &population.entities[members[population.rng.Int(0, len(members))]]
+			var partnerB = &population.entities[members[population.rng.Int(0, len(members))]]
+			var child, err = dnaCrossover(population.rng, partnerA, partnerB)
+			if err != nil {
+				child = partnerA.Clone()
+			}
+			dnaMutate(population.rng, &child, population.cfg.MutationRate, population.cfg.Alphabet)
+			children = append(children, child)
+		}
+	}
+
+	var entities = make([]DNA, 0, len(population.entities)-len(removed)+len(children))
+	for i := range population.entities {
+		if !removed[i] {
+			entities = append(entities, population.entities[i])
+		}
+	}
+	population.entities = append(entities, children...)
+
+	populationCalculateFitness(population, population.cfg.Target)
+}
+
+/**
+ * Population: Worst Order
+ * Returns the indexes of the population's entities ordered from the worst
+ * (lowest fitness) to the best
+ */
+func populationWorstOrder(population *Population) []int {
+	var order = make([]int, len(population.entities))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return population.entities[order[i]].fitness < population.entities[order[j]].fitness
+	})
+
+	return order
+}
+
+/**
+ * Population: Top K
+ * Returns copies of the k fittest entities, sorted by descending fitness
+ * k is clamped to the size of the population
+ */
+func PopulationTopK(population *Population, k int) []DNA {
+	var order = make([]int, len(population.entities))
+	for i := range order {
+		order[i] = i
+	}
+
+	// Ties keep population order, so the first entity matches populationGetBest
+	sort.SliceStable(order, func(i, j int) bool {
+		return population.entities[order[i]].fitness > population.entities[order[j]].fitness
+	})
+
+	return populationCloneOrder(population, order, k)
+}
+
+/**
+ * Population: Bottom K
+ * Returns copies of the k weakest entities, sorted by ascending fitness
+ * k is clamped to the size of the population
+ */
+func PopulationBottomK(population *Population, k int) []DNA {
+	return populationCloneOrder(population, populationWorstOrder(population), k)
+}
+
+/**
+ * Migrate Top K
+ * Exports copies of the k fittest entities, for use outside of the population
+ * (such as warm-starting another run). The population is left unmodified.
+ */
+func MigrateTopK(population *Population, k int) []DNA {
+	return PopulationTopK(population, k)
+}
+
+/**
+ * Inject Entities
+ * Replaces the population's worst entities with copies of the given entities,
+ * appending any beyond the size of the population. Each copy is a new entity
+ * descended from the one it was copied from, as a clonal child is. Their fitness
+ * is recalculated with the rest of the population.
+ */
+func InjectEntities(population *Population, entities []DNA) {
+	var order = populationWorstOrder(population)
+
+	for i := range entities {
+		var injected = entities[i].Clone()
+		injected.ParentIDs = [2]int64{entities[i].ID, 0}
+		injected.ID = dnaNewID()
+
+		if i < len(order) {
+			population.entities[order[i]] = injected
+		} else {
+			population.entities = append(population.entities, injected)
+		}
+	}
+
+	population.CacheDirty = true
+}
+
+/**
+ * Population: Clone Order
+ * Returns copies of the entities at the first k of the given indexes
+ */
+func populationCloneOrder(population *Population, order []int, k int) []DNA {
+	if k > len(order) {
+		k = len(order)
+	}
+	if k < 0 {
+		k = 0
+	}
+
+	var entities = make([]DNA, k)
+	for i := range entities {
+		entities[i] = population.entities[order[i]].Clone()
+	}
+
+	return entities
+}
+
+/**
+ * Population: Get Best
+ * Gets the best phrase generated by the entity of the current population with
+ * the highest fitness (here known as the "world record")
+ * The best entity is cached when fitness is calculated, so repeated calls within
+ * a generation do not rescan the population.
+ */
+func populationGetBest(population *Population) string {
+	if population.CacheDirty {
+		populationUpdateBest(population)
+	}
+
+	if population.bestFitness == population.perfectScore {
+		population.completed = true
+	}
+
+	return dnaExtractPhrase(&population.entities[population.bestIndex])
+}
+
+/**
+ * Population: Update Best
+ * Finds the entity of the current population with the highest fitness, and
+ * caches its index and fitness
+ */
+func populationUpdateBest(population *Population) {
+	var worldrecord float32
+	var index int
+
+	for i := 0; i < len(population.entities); i++ {
+		if population.entities[i].fitness > worldrecord {
+			index = i
+			worldrecord = population.entities[i].fitness
+		}
+	}
+
+	population.bestIndex = index
+	population.bestFitness = worldrecord
+	population.CacheDirty = false
+}
+
+/**
+ * Population: Average Fitness
+ * Calculates and returns the average fitness for the current generation of
+ * the population
+ */
+func populationAverageFitness(population *Population) float32 {
+	var total float32
+	for i := 0; i < len(population.entities); i++ {
+		total += population.entities[i].fitness
+	}
+	return total / float32(len(population.entities))
+}
+
+/**
+ * Population: Weighted Average Fitness
+ * Calculates the average fitness of the current generation, with each entity's
+ * fitness counting for the weight at its index, such as InverseAgeWeights to
+ * count older entities for less. Weights summing to 0 average 0.
+ * Returns ErrPopulationSizeMismatch unless there is one weight per entity.
+ */
+func populationAverageFitnessWeighted(p *Population, weights []float32) (float32, error) {
+	if len(weights) != len(p.entities) {
+		return 0, ErrPopulationSizeMismatch{len(weights), len(p.entities)}
+	}
+
+	var total, sum float32
+	for i := range p.entities {
+		total += weights[i] * p.entities[i].fitness
+		sum += weights[i]
+	}
+	if sum == 0 {
+		return 0, nil
+	}
+
+	return total / sum, nil
+}
+
+/**
+ * Uniform Weights
+ * Returns n equal weights, with which the weighted average is the average
+ */
+func UniformWeights(n int) []float32 {
+	var weights = make([]float32, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	return weights
+}
+
+/**
+ * Inverse Age Weights
+ * Returns a weight of 1 / (1 + age) for each entity, so that the older an
+ * entity, the less it counts
+ */
+func InverseAgeWeights(entities []DNA) []float32 {
+	var weights = make([]float32, len(entities))
+	for i := range entities {
+		weights[i] = 1 / float32(1+entities[i].Age)
+	}
+
+	return weights
+}
+
+/**
+ * Population: Median Fitness
+ * Returns the middle fitness of the current generation, or the average of the
+ * middle two for an even number of entities
+ */
+func populationMedianFitness(population *Population) float32 {
+	return populationPercentileFitness(population, 50)
+}
+
+/**
+ * Population: Percentile Fitness
+ * Returns the fitness below which p percent (0 - 100) of the current
+ * generation lies, interpolating linearly between the closest two entities
+ */
+func populationPercentileFitness(population *Population, p float32) float32 {
+	if len(population.entities) == 0 {
+		return 0
+	}
+
+	var fitness = make([]float64, len(population.entities))
+	for i := range population.entities {
+		fitness[i] = float64(population.entities[i].fitness)
+	}
+	sort.Float64s(fitness)
+
+	var rank = float64(clamp(float64(p), [2]float64{0, 100})) / 100 * float64(len(fitness)-1)
+	var lower = int(math.Floor(rank))
+	var upper = int(math.Ceil(rank))
+
+	return float32(fitness[lower] + (fitness[upper]-fitness[lower])*(rank-float64(lower)))
+}
+
+/**
+ * Population: Generation Stats
+ * Summarises the fitness of the population's current generation
+ */
+func PopulationGenerationStats(population *Population) GenerationStats {
+	var best float32
+	var bestPhrase string
+	for i := range population.entities {
+		if i == 0 || population.entities[i].fitness > best {
+			best = population.entities[i].fitness
+			bestPhrase = dnaExtractPhrase(&population.entities[i])
+		}
+	}
+
+	return GenerationStats{
+		Generation:     population.generations,
+		BestFitness:    best,
+		BestPhrase:     bestPhrase,
+		AverageFitness: populationAverageFitness(population),
+		MedianFitness:  populationMedianFitness(population),
+		LowerQuartile:  populationPercentileFitness(population, 25),
+		UpperQuartile:  populationPercentileFitness(population, 75),
+		FixedAlleles:   len(DetectFixedAlleles(population)),
+	}
+}
+
+/**
+ * Format Progress Bar
+ * Draws the generation's best fitness as a bar of the given width, followed by
+ * the percentage and the best phrase, as in:
+ * Gen 42 [=========>          ] 45% Best: "I think, the"
+ */
+func FormatProgressBar(stats GenerationStats, width int) string {
+	var fitness = clamp(float64(stats.BestFitness), [2]float64{0, 1})
+	var filled = int(fitness * float64(width))
+
+	var bar = strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	if filled > 0 && filled < width {
+		bar = bar[:filled-1] + ">" + bar[filled:]
+	}
+
+	return fmt.Sprintf("Gen %d [%s] %d%% Best: %q", stats.Generation, bar, int(fitness*100), stats.BestPhrase)
+}
+
+/**
+ * Phase Timer: Record
+ * Adds the time spent in each phase of one generation
+ */
+func (t *PhaseTimer) record(selection, generate, fitness time.Duration) {
+	t.Selection += selection
+	t.Generate += generate
+	t.Fitness += fitness
+	t.Generations++
+}
+
+/**
+ * Phase Timer: Average Per Generation
+ * Returns the average time spent in each phase per generation timed, as a
+ * timer of a single generation. A timer yet to time any generation averages 0.
+ */
+func (t PhaseTimer) AveragePerGeneration() PhaseTimer {
+	if t.Generations == 0 {
+		return PhaseTimer{}
+	}
+
+	var n = time.Duration(t.Generations)
+	return PhaseTimer{
+		Selection:   t.Selection / n,
+		Generate:    t.Generate / n,
+		Fitness:     t.Fitness / n,
+		Generations: 1,
+	}
+}
+
+/**
+ * New Event Log
+ * Creates (or truncates) the file at the given path, and returns an event log
+ * writing to it. The log must be closed once finished with.
+ */
+func NewEventLog(path string) (*EventLog, error) {
+	var file, err = os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventLog{w: bufio.NewWriter(file), file: file}, nil
+}
+
+/**
+ * Event Log: Log
+ * Writes the event, the current time and the generation's stats as one line of
+ * JSON. Safe for concurrent use.
+ */
+func (l *EventLog) Log(event string, stats GenerationStats) error {
+	var line, err = json.Marshal(struct {
+		Timestamp  time.Time `json:"ts"`
+		Event      string    `json:"event"`
+		Generation int       `json:"gen"`
+		Best       float32   `json:"best"`
+		Average    float32   `json:"avg"`
+		Phrase     string    `json:"phrase"`
+	}{time.Now(), event, stats.Generation, stats.BestFitness, stats.AverageFitness, stats.BestPhrase})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Event Log: Close
+ * Flushes any buffered events and closes the file
+ */
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.w.Flush(); err != nil {
+		l.file.Close()
+		return err
+	}
+
+	return l.file.Close()
+}
+
+/**
+ * Population DOT
+ * Describes the evolution tree as a Graphviz DOT digraph, with a node for each
+ * entity and an edge from each parent to its child. The current entities are
+ * labelled with their phrase. With a recorder, every entity it has recorded is
+ * included, otherwise only the current entities and their parents.
+ */
+func PopulationDot(population *Population, recorder *PopulationRecorder) string {
+	var lineage = make(map[int64][2]int64)
+	if recorder != nil {
+		for id, parents := range recorder.Lineage {
+			lineage[id] = parents
+		}
+	}
+
+	var labels = make(map[int64]string, len(population.entities))
+	for i := range population.entities {
+		lineage[population.entities[i].ID] = population.entities[i].ParentIDs
+		labels[population.entities[i].ID] = dnaExtractPhrase(&population.entities[i])
+	}
+
+	// Parents which were never recorded (such as generation 0) are roots
+	var ids = make([]int64, 0, len(lineage))
+	for id, parents := range lineage {
+		ids = append(ids, id)
+		for _, parent := range parents {
+			if _, ok := lineage[parent]; parent != 0 && !ok {
+				lineage[parent] = [2]int64{}
+				ids = append(ids, parent)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var dot strings.Builder
+	dot.WriteString("digraph evolution {\n")
+	for _, id := range ids {
+		if label, ok := labels[id]; ok {
+			fmt.Fprintf(&dot, "\tn%d [label=%q];\n", id, label)
+		} else {
+			fmt.Fprintf(&dot, "\tn%d;\n", id)
+		}
+	}
+	for _, id := range ids {
+		var parents = lineage[id]
+		for i, parent := range parents {
+			// A clone, or a child of a parent crossed with itself, has one parent
+			if parent != 0 && (i == 0 || parent != parents[0]) {
+				fmt.Fprintf(&dot, "\tn%d -> n%d;\n", parent, id)
+			}
+		}
+	}
+	dot.WriteString("}\n")
+
+	return dot.String()
+}
+
+/**
+ * Filter Population
+ * Returns copies of the entities matching the predicate, in population order.
+ * The population is left unchanged.
  */
-type DNA struct {
-	genes   []rune
-	fitness float32
+func FilterPopulation(population *Population, pred func(*DNA) bool) []DNA {
+	var matching = []DNA{}
+	for i := range population.entities {
+		if pred(&population.entities[i]) {
+			matching = append(matching, population.entities[i].Clone())
+		}
+	}
+
+	return matching
 }
 
 /**
- * Population
- * Holds the entities of the population, the mating pool, and iteration information
+ * Count If
+ * Counts the entities matching the predicate
  */
-type Population struct {
-	entities     []DNA
-	matingPool   []DNA
-	generations  int
-	completed    bool
-	perfectScore float32
+func CountIf(population *Population, pred func(*DNA) bool) int {
+	var count int
+	for i := range population.entities {
+		if pred(&population.entities[i]) {
+			count++
+		}
+	}
+
+	return count
 }
 
 /**
- * Main Method
- * Sets up the initial generation, then runs the evolution loop until an entity
- * matches the target 100%
+ * Above Fitness
+ * A predicate matching entities fitter than the threshold
  */
-func main() {
-	fmt.Println("Danw33's Golang-based Genetic Algorithm")
-	fmt.Println("Start time:", time.Now())
-	fmt.Println("Running with Max Population:", maxpop, "and Mutation Probability:", mutrate)
-	fmt.Println("Target Outcome: ", target)
+func AboveFitness(threshold float32) func(*DNA) bool {
+	return func(dna *DNA) bool {
+		return dna.fitness > threshold
+	}
+}
 
-	// Seed the PRNG (only once!)
-	rand.Seed(time.Now().Unix())
+/**
+ * Below Fitness
+ * A predicate matching entities less fit than the threshold
+ */
+func BelowFitness(threshold float32) func(*DNA) bool {
+	return func(dna *DNA) bool {
+		return dna.fitness < threshold
+	}
+}
 
-	// Sanity Check
-	//test()
+/**
+ * Map Population
+ * Returns a new population holding fn applied to each of the entities, with the
+ * same generation count, mating pool, config and other state. fn is given a
+ * copy of each entity, so the original population is left unchanged.
+ * A population holds a lock, so it is returned by pointer rather than value.
+ */
+func MapPopulation(p *Population, fn func(DNA) DNA) *Population {
+	var mapped = populationShallowCopy(p)
 
-	var population = Population{[]DNA{}, []DNA{}, 0, false, 1.0}
+	mapped.entities = make([]DNA, len(p.entities))
+	for i := range p.entities {
+		mapped.entities[i] = fn(p.entities[i].Clone())
+	}
 
-	// Run the setup method (Create Generation 0)
-	setup(&population)
+	return mapped
+}
 
-	// Evolve
-	for population.completed == false {
-		evolve(&population)
+/**
+ * Reduce Population
+ * Folds the entities into a single DNA, calling fn with the result so far
+ * (starting from init) and each entity in turn
+ */
+func ReducePopulation(p *Population, fn func(acc, dna DNA) DNA, init DNA) DNA {
+	var acc = init
+	for i := range p.entities {
+		acc = fn(acc, p.entities[i])
 	}
 
-	fmt.Println("Solution Discovered at", time.Now(), "by Generation", population.generations, "with population", len(population.entities), "and mutation rate", mutrate, " Average fitness:", populationAverageFitness(&population), "Final Phrase:", populationGetBest(&population))
+	return acc
 }
 
 /**
- * Initial Setup Method
- * Generates Generation 0 of the population with all-new DNA (Random)
+ * Consensus Sequence
+ * Returns the most common rune at each gene position across the entities (the
+ * first seen on a tie), with its fitness assessed against the target. A
+ * consensus with a fitness of 1.0 means the population has converged on the
+ * target.
  */
-func setup(population *Population) {
-	fmt.Println("Setting up at", time.Now())
+func ConsensusSequence(p *Population) DNA {
+	var counts []map[rune]int
 
-	fmt.Println("Populating Generation 0 Gene Pool with random DNA Geonomes")
-	for i := 0; i < maxpop; i++ {
-		var newDna = DNA{}
-		dnaCreate(&newDna, len(target))
-		population.entities = append(population.entities, newDna)
-	}
+	var consensus = ReducePopulation(p, func(acc, dna DNA) DNA {
+		for i, gene := range dna.genes {
+			if i == len(acc.genes) {
+				acc.genes = append(acc.genes, gene)
+				counts = append(counts, make(map[rune]int))
+			}
 
-	fmt.Println("Created Seed Entities:", len(population.entities))
+			counts[i][gene]++
+			if counts[i][gene] > counts[i][acc.genes[i]] {
+				acc.genes[i] = gene
+			}
+		}
+		return acc
+	}, DNA{genes: []rune{}})
 
-	fmt.Println("Calculating Generation 0 Fitness")
-	populationCalculateFitness(population, target)
-	fmt.Println("Generation 0 Fitness has been calculated.")
+	dnaAssessFitness(&consensus, p.cfg.Target)
 
-	fmt.Println("Setup Completed at", time.Now())
+	return consensus
 }
 
 /**
- * Evolution Loop Method
- * Runs the Natural Selection, Generation, Fitness cycle
- * To be called in a loop until the population flags itself as completed.
+ * Zip Populations
+ * Returns a new population holding fn applied to each pair of entities at the
+ * same index of a and b, with the rest of a's state (see MapPopulation)
+ * Returns ErrPopulationSizeMismatch if the populations differ in size.
  */
-func evolve(population *Population) {
-	// Generate mating pool
-	populationNaturalSelection(population)
+func ZipPopulations(a, b *Population, fn func(DNA, DNA) DNA) (*Population, error) {
+	if len(a.entities) != len(b.entities) {
+		return nil, ErrPopulationSizeMismatch{len(a.entities), len(b.entities)}
+	}
 
-	// Create next generation
-	populationGenerate(population)
+	var zipped = populationShallowCopy(a)
 
-	// Calculate fitness
-	populationCalculateFitness(population, target)
+	zipped.entities = make([]DNA, len(a.entities))
+	for i := range a.entities {
+		zipped.entities[i] = fn(a.entities[i].Clone(), b.entities[i].Clone())
+	}
 
-	// Display Info
-	fmt.Println("Generation", population.generations, "with population", maxpop, "and mutation rate", mutrate, "completed with average fitness", populationAverageFitness(population), "Best Phrase:", populationGetBest(population))
+	return zipped, nil
+}
+
+/**
+ * Voting Crossover
+ * Returns a child taking each gene the parents agree on, and a gene drawn from
+ * either parent at random where they disagree. Genes of a beyond the length of
+ * b are taken from a. To zip populations with it, close over the PRNG.
+ */
+func VotingCrossover(rng *PRNG, a, b DNA) DNA {
+	var child = DNA{genes: make([]rune, len(a.genes))}
+	for i := range a.genes {
+		if i < len(b.genes) && a.genes[i] != b.genes[i] && rng.Int(0, 2) == 1 {
+			child.genes[i] = b.genes[i]
+		} else {
+			child.genes[i] = a.genes[i]
+		}
+	}
 
+	return child
 }
 
-func test() {
+/**
+ * Population: Shallow Copy
+ * Copies the population's state field by field, leaving its lock and recycled
+ * DNA behind. The mating pool is deep copied, as its genes are recycled once the
+ * original breeds again. The entities are left to the caller, and the cached
+ * best entity is marked dirty.
+ */
+func populationShallowCopy(p *Population) *Population {
+	var matingPool = make([]DNA, len(p.matingPool))
+	for i := range p.matingPool {
+		matingPool[i] = p.matingPool[i].Clone()
+	}
 
-	fmt.Println("Running basic test. Will Generate two parents, crossover and mutuate.")
+	return &Population{
+		matingPool:             matingPool,
+		generations:            p.generations,
+		completed:              p.completed,
+		perfectScore:           p.perfectScore,
+		cfg:                    p.cfg,
+		CacheDirty:             true,
+		MutationAdaptor:        p.MutationAdaptor,
+		baseMutationRate:       p.baseMutationRate,
+		rng:                    p.rng,
+		hypermutationCountdown: p.hypermutationCountdown,
+	}
+}
 
-	var dnaA = DNA{}
-	dnaCreate(&dnaA, len(target))
-	dnaAssessFitness(&dnaA, target)
-	fmt.Println("Parent 1 (DNA A) Fitness:", dnaA.fitness, "Phrase:", dnaExtractPhrase(&dnaA))
+/**
+ * Detect Fixed Alleles
+ * Returns the gene positions at which every entity shares the same rune (the
+ * allele has reached fixation), in order. Many fixed alleles mean the
+ * population has lost its diversity at those positions, through selection or
+ * genetic drift.
+ */
+func DetectFixedAlleles(population *Population) []int {
+	var fixed = []int{}
 
-	var dnaB = DNA{}
-	dnaCreate(&dnaB, len(target))
-	dnaAssessFitness(&dnaB, target)
-	fmt.Println("Parent 2 (DNA B) Fitness:", dnaB.fitness, "Phrase:", dnaExtractPhrase(&dnaB))
+	for pos := 0; pos < populationGeneLength(population); pos++ {
+		var allele = population.entities[0].genes[pos]
+		var isFixed = true
+		for i := 1; i < len(population.entities) && isFixed; i++ {
+			isFixed = population.entities[i].genes[pos] == allele
+		}
+		if isFixed {
+			fixed = append(fixed, pos)
+		}
+	}
 
-	var dnaC = dnaCrossover(&dnaA, &dnaB)
-	dnaMutate(&dnaC, mutrate)
-	dnaAssessFitness(&dnaC, target)
-	fmt.Println("Child    (DNA C) Fitness:", dnaC.fitness, "Phrase:", dnaExtractPhrase(&dnaC))
+	return fixed
+}
 
-	fmt.Println("Manipulating Child geonome (DNA C => DNA D) to test fitness assessment")
+/**
+ * Allele Frequency Map
+ * Returns the fraction of entities holding each rune (allele) at the given
+ * gene position
+ * Returns ErrGeneRange if the position lies outside of the genes.
+ */
+func AlleleFrequencyMap(p *Population, pos int) (map[rune]float32, error) {
+	if pos < 0 || pos >= populationGeneLength(p) {
+		return nil, ErrGeneRange{pos, pos + 1, populationGeneLength(p)}
+	}
 
-	var dnaD = DNA{}
-	var mutatedGenes []rune
-	mutatedGenes = append(mutatedGenes, rune(target[0])) // Mutate the gene at the position 0
-	mutatedGenes = append(mutatedGenes, rune(target[1])) // Mutate the gene at the position 1
-	mutatedGenes = append(mutatedGenes, rune(target[2])) // Mutate the gene at the position 2
-	mutatedGenes = append(mutatedGenes, dnaC.genes[3:]...)
-	dnaD.genes = mutatedGenes
+	var frequencies = make(map[rune]float32)
+	for i := range p.entities {
+		frequencies[p.entities[i].genes[pos]]++
+	}
+	for allele := range frequencies {
+		frequencies[allele] /= float32(len(p.entities))
+	}
 
-	dnaAssessFitness(&dnaD, target)
-	fmt.Println("Child    (DNA D) Fitness:", dnaD.fitness*100, "Phrase:", dnaExtractPhrase(&dnaD))
+	return frequencies, nil
+}
 
-	fmt.Println("Testing concluded, see console for data to analyse.")
+/**
+ * Population Allele Frequencies
+ * Returns the allele frequency map (see AlleleFrequencyMap) of every gene
+ * position, in order
+ */
+func PopulationAlleleFrequencies(p *Population) []map[rune]float32 {
+	var frequencies = make([]map[rune]float32, populationGeneLength(p))
+	for pos := range frequencies {
+		frequencies[pos], _ = AlleleFrequencyMap(p, pos)
+	}
+
+	return frequencies
 }
 
 /**
- * Random Int Generator with Range Restriction
- * Generates a random int within the given min and max parameters
- * Uses math/rand library
+ * Schema Match Count
+ * Counts the entities whose genes match the schema
  */
-func random(min, max int) int {
-	return rand.Intn(max-min) + min
+func SchemaMatchCount(p *Population, s Schema) int {
+	return CountIf(p, s.Matches)
 }
 
 /**
- * Random Float Generator with Range Restriction
- * Generates a random float within the given min and max parameters
- * Uses math/rand library
+ * Schema Average Fitness
+ * Returns the average fitness of the entities matching the schema, or 0 if
+ * none do. Tracked over generations, short, low-order schemata of above
+ * average fitness should be seen to grow in frequency.
  */
-func randomFloat(min, max float32) float32 {
-	return rand.Float32()*(max-min) + min
+func SchemaAverageFitness(p *Population, s Schema) float32 {
+	var matching = FilterPopulation(p, s.Matches)
+	if len(matching) == 0 {
+		return 0
+	}
+
+	var total float32
+	for i := range matching {
+		total += matching[i].fitness
+	}
+
+	return total / float32(len(matching))
 }
 
 /**
- * Re-maps a number from one range to another
- * Danw33's Golang interpretation of the Arduino Math Library map method
- * @see https://www.arduino.cc/reference/en/language/functions/math/map/
+ * Schema: Matches
+ * Reports whether the dna's genes match every fixed (non-wildcard) position of
+ * the schema. Genes of a different length never match.
  */
-func highLowMap(x, inMin, inMax, outMin, outMax float32) float32 {
-	return (x-inMin)*(outMax-outMin)/(inMax-inMin) + outMin
+func (s Schema) Matches(dna *DNA) bool {
+	if len(dna.genes) != len(s.Pattern) {
+		return false
+	}
+
+	for i, r := range s.Pattern {
+		if r != s.WildCard && dna.genes[i] != r {
+			return false
+		}
+	}
+
+	return true
 }
 
 /**
- * DNA: Create New, Random DNA
- * Creates n new DNA genes,
- * Appends them to the genes array (rune slice) in the given dna struct pointer
+ * Population: Gene Length
+ * Returns the length of the shortest entity's genes, the positions every
+ * entity has a gene for, or 0 for an empty population
  */
-func dnaCreate(dna *DNA, n int) {
-	for i := 0; i < n; i++ {
-		dna.genes = append(dna.genes, rune(random(32, 128))) // Pick from range of chars
+func populationGeneLength(population *Population) int {
+	if len(population.entities) == 0 {
+		return 0
+	}
+
+	var geneLength = len(population.entities[0].genes)
+	for i := range population.entities {
+		if len(population.entities[i].genes) < geneLength {
+			geneLength = len(population.entities[i].genes)
+		}
 	}
+
+	return geneLength
 }
 
 /**
- * DNA: Extract the genes as a string
- * Built from the genes rune slice in the given dna pointer
+ * Population: Unique Count
+ * Counts the distinct phrases held by the population's entities
  */
-func dnaExtractPhrase(dna *DNA) string {
-	return string(dna.genes)
+func PopulationUniqueCount(population *Population) int {
+	var unique = make(map[string]bool, len(population.entities))
+	for i := range population.entities {
+		unique[dnaExtractPhrase(&population.entities[i])] = true
+	}
+
+	return len(unique)
 }
 
 /**
- * DNA: Fitness Assessment Method
- * Sets a percentage (float32) of "correct" runes (how close to the target) on
- * the given dna pointer
+ * Restart Population
+ * Replaces every entity except the EliteCount fittest with a new one of fresh
+ * random DNA, to escape a local optimum the population has converged on
  */
-func dnaAssessFitness(dna *DNA, target string) {
-	var score int
-	var runeTarget = []rune(target)
+func RestartPopulation(population *Population) {
+	var order = populationWorstOrder(population)
+	var restarts = len(order) - population.cfg.EliteCount
+	if restarts < 0 {
+		restarts = 0
+	}
 
-	for i := 0; i < len(dna.genes); i++ {
-		if dna.genes[i] == runeTarget[i] {
-			score++
-		}
+	for _, i := range order[:restarts] {
+		// Like those created at setup, the new entity has no age or parents
+		var fresh = DNA{ID: dnaNewID()}
+		dnaCreate(population.rng, &fresh, len(population.entities[i].genes), population.cfg.Alphabet)
+		population.entities[i] = fresh
 	}
 
-	dna.fitness = float32(score) / float32(len(target))
+	population.matingPool = population.matingPool[:0]
+	populationCalculateFitness(population, population.cfg.Target)
 }
 
 /**
- * DNA: Crossover Method
- * Takes two DNA Parents, and returns a DNA Child that has genes spliced from
- * both parents
+ * Population: All Phrases
+ * Outputs the phrases held by up to limit entities within the current population,
+ * one per line. A limit of 0 or less outputs every entity.
+ * Logged each generation when the population's config is verbose, to help with
+ * debugging.
  */
-func dnaCrossover(partnerA *DNA, partnerB *DNA) DNA {
-	// Create a new child
-	var child = DNA{}
+func PopulationAllPhrases(population *Population, limit int) string {
+	var displayLimit = len(population.entities)
+	if limit > 0 && limit < displayLimit {
+		displayLimit = limit
+	}
 
-	// Pick a midpoint in the genes
-	var midpoint = random(0, len(partnerA.genes))
-
-	// Half from one, half from the other
-	for i := 0; i < len(partnerA.genes); i++ {
-		if i > midpoint {
-			// Before the midpoint, take partner A's genes
-			// In Java: child.genes[i] = partnerA.genes[i];
-			child.genes = append(child.genes, partnerA.genes[i])
-		} else {
-			// After the midpoint, take partner B's genes
-			child.genes = append(child.genes, partnerB.genes[i])
-		}
+	var phrases = make([]string, displayLimit)
+	for i := range phrases {
+		phrases[i] = dnaExtractPhrase(&population.entities[i])
 	}
 
-	// Return the new child
-	return child
+	return strings.Join(phrases, "\n")
 }
 
 /**
- * DNA: Mutation Method
- * Mutates the genes of the given entity, within the given mutation rate (probability)
+ * Ring Topology
+ * Connects each island to the next, with the last connected to the first
  */
-func dnaMutate(entity *DNA, rate float32) {
-	for i := 0; i < len(entity.genes); i++ {
-		if randomFloat(0.0, 1.0) < rate {
-			// In Java: genes[i] = (char) random(32,128);
-			var mutatedGenes []rune
-			mutatedGenes = append(mutatedGenes, entity.genes[:i]...)   // NB: append() is a variadic function
-			mutatedGenes = append(mutatedGenes, rune(random(32, 128))) // Mutate the gene at the random position
-			mutatedGenes = append(mutatedGenes, entity.genes[i+1:]...) // the ... lets you us multiple arguments to a variadic function from a slice
-			entity.genes = mutatedGenes
+func RingTopology(island, count int) []int {
+	if count < 2 {
+		return nil
+	}
+	return []int{(island + 1) % count}
+}
+
+/**
+ * Fully Connected Topology
+ * Connects each island to every other island
+ */
+func FullyConnectedTopology(island, count int) []int {
+	var connected []int
+	for i := 0; i < count; i++ {
+		if i != island {
+			connected = append(connected, i)
 		}
 	}
+	return connected
 }
 
 /**
- * Population: Run a fitness assessment on every current member of the population
+ * Island Model: Evolve
+ * Evolves every island by one generation, then migrates entities every
+ * MigrationInterval generations and performs inter-island crossover with the
+ * configured probability
  */
-func populationCalculateFitness(population *Population, target string) {
-	for i := 0; i < len(population.entities); i++ {
-		dnaAssessFitness(&population.entities[i], target)
+func (m *IslandModel) Evolve() error {
+	for _, island := range m.Islands {
+		if err := evolve(island); err != nil {
+			return err
+		}
+	}
+
+	m.generations++
+
+	var migrate = m.MigrationInterval > 0 && m.generations%m.MigrationInterval == 0
+	if m.DynamicMigrationInterval {
+		migrate = m.Divergence() > m.DivergenceThreshold
+	}
+	if migrate {
+		IslandMigrate(m.Islands, m.Topology, m.MigrationCount)
+		m.migrations++
+	}
+
+	if m.PRNG.Float32(0.0, 1.0) < m.InterIslandCrossoverRate {
+		return IslandCrossover(m.PRNG, m.Islands, m.Topology)
 	}
+
+	return nil
 }
 
 /**
- * Population: Mating Pool Generator
- * Performs Natural Selection on the current generation of entities, and creates
- * a mating pool of DNA candidates to become parents.
+ * Island Model: Divergence
+ * Measures how far the islands have differentiated, as the average Hamming
+ * distance between the best entities of each pair of islands
  */
-func populationNaturalSelection(population *Population) {
-	// Reset the mating pool first
-	population.matingPool = []DNA{}
+func (m *IslandModel) Divergence() float64 {
+	var best = make([]*DNA, len(m.Islands))
+	for i, island := range m.Islands {
+		if island.CacheDirty {
+			populationUpdateBest(island)
+		}
+		best[i] = &island.entities[island.bestIndex]
+	}
 
-	var maxFitness float32
+	var total float64
+	var pairs int
+	for i := range best {
+		for j := i + 1; j < len(best); j++ {
+			if distance := HammingDistance(best[i], best[j]); distance >= 0 {
+				total += float64(distance)
+				pairs++
+			}
+		}
+	}
 
-	// Find the fittest entity in the current population
-	for i := 0; i < len(population.entities); i++ {
-		if population.entities[i].fitness > maxFitness {
-			maxFitness = population.entities[i].fitness
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+/**
+ * Island Migrate
+ * Copies the best count entities of each island over the worst entities of the
+ * islands connected to it
+ */
+func IslandMigrate(islands []*Population, topology MigrationTopology, count int) {
+	// Pick every island's migrants before any island is changed
+	var migrants = make([][]DNA, len(islands))
+	for i, island := range islands {
+		var order = populationWorstOrder(island)
+		for j := len(order) - 1; j >= 0 && len(order)-j <= count; j-- {
+			migrants[i] = append(migrants[i], island.entities[order[j]].Clone())
 		}
 	}
 
-	// Each member of the current population will be added to the new mating pool a given number of times
-	// based on their assessed fitnes. The higher the fitness, the more entries a single entity will have
-	// therefore increasing the chances of a fitter child being produced (Natural Selection)
-	for i := 0; i < len(population.entities); i++ {
-		var fitness = highLowMap(population.entities[i].fitness, 0, maxFitness, 0, 1)
-		var n = int(fitness * 100) // Like the book we use an Arbitrary multiplier. An alternative would be the monte carlo method.
-		for j := 0; j < n; j++ {   // We pick out two random numbers
-			population.matingPool = append(population.matingPool, population.entities[i])
+	for i := range islands {
+		for _, target := range topology(i, len(islands)) {
+			var order = populationWorstOrder(islands[target])
+			for j := 0; j < len(migrants[i]) && j < len(order); j++ {
+				islands[target].entities[order[j]] = migrants[i][j].Clone()
+			}
+			islands[target].CacheDirty = true
 		}
 	}
 }
 
 /**
- * Population: Generation Iteration
- * Replaces the population's entities with the new entities generated
- * from the mating pool, performing DNA crossover and mutation.
+ * Island Crossover
+ * Crosses over a random entity of a random island with a random entity of an
+ * island connected to it, and inserts the child over the worst entity of a
+ * randomly selected island
  */
-func populationGenerate(population *Population) {
-	// Refill the population with children from the mating pool
-	for i := 0; i < len(population.entities); i++ {
-		var a, b int
-		a = int(random(0, len(population.matingPool)))
-		b = int(random(0, len(population.matingPool)))
+func IslandCrossover(rng *PRNG, islands []*Population, topology MigrationTopology) error {
+	if len(islands) < 2 {
+		return nil
+	}
 
-		var partnerA, partnerB, child DNA
-		partnerA = population.matingPool[a]
-		partnerB = population.matingPool[b]
-		child = dnaCrossover(&partnerA, &partnerB)
+	var a = rng.Int(0, len(islands))
+	var connected = topology(a, len(islands))
+	if len(connected) == 0 {
+		return nil
+	}
+	var b = connected[rng.Int(0, len(connected))]
 
-		dnaMutate(&child, mutrate)
-		population.entities[i] = child
+	var partnerA = &islands[a].entities[rng.Int(0, len(islands[a].entities))]
+	var partnerB = &islands[b].entities[rng.Int(0, len(islands[b].entities))]
+	var child, err = dnaCrossover(rng, partnerA, partnerB)
+	if err != nil {
+		return err
 	}
+	child.ParentIDs = [2]int64{partnerA.ID, partnerB.ID}
+	child.ID = dnaNewID()
 
-	population.generations++
+	var target = islands[rng.Int(0, len(islands))]
+	target.entities[populationWorstOrder(target)[0]] = child
+	target.CacheDirty = true
+
+	// Assess the newcomer alongside the rest of its island
+	populationCalculateFitness(target, target.cfg.Target)
+
+	return nil
 }
 
 /**
- * Population: Get Best
- * Gets the best phrase generated by the entity of the current population with
- * the highest fitness (here known as the "world record")
+ * Coevolution Pair: Evolve
+ * Alternates evolving the hosts and the parasites, one generation each, until
+ * the context is done or a generation fails
  */
-func populationGetBest(population *Population) string {
-	var worldrecord float32
-	var index int
+func (c *CoevolutionPair) Evolve(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	for i := 0; i < len(population.entities); i++ {
-		if population.entities[i].fitness > worldrecord {
-			index = i
-			worldrecord = population.entities[i].fitness
+		if err := c.Step(); err != nil {
+			return err
 		}
 	}
+}
 
-	if worldrecord == population.perfectScore {
-		population.completed = true
+/**
+ * Coevolution Pair: Step
+ * Evolves the hosts by one generation against the best current parasite, then
+ * the parasites by one generation against the best new host. Each population
+ * is evolved holding its lock, as evolve does.
+ */
+func (c *CoevolutionPair) Step() error {
+	var parasite = coevolutionBest(c.ParasitePopulation)
+
+	var err = evolveAssessing(c.HostPopulation, func(hosts *Population) {
+		for i := range hosts.entities {
+			hosts.entities[i].fitness, _ = c.InteractionFn(&hosts.entities[i], &parasite)
+		}
+		hosts.CacheDirty = true
+	})
+	if err != nil {
+		return err
 	}
 
-	return dnaExtractPhrase(&population.entities[index])
+	var host = coevolutionBest(c.HostPopulation)
+
+	return evolveAssessing(c.ParasitePopulation, func(parasites *Population) {
+		for i := range parasites.entities {
+			_, parasites.entities[i].fitness = c.InteractionFn(&host, &parasites.entities[i])
+		}
+		parasites.CacheDirty = true
+	})
 }
 
 /**
- * Population: Average Fitness
- * Calculates and returns the average fitness for the current generation of
- * the population
+ * Coevolution Best
+ * Returns a copy of the fittest entity of the given population, holding its
+ * lock
  */
-func populationAverageFitness(population *Population) float32 {
-	var total float32
-	for i := 0; i < len(population.entities); i++ {
-		total += population.entities[i].fitness
+func coevolutionBest(population *Population) DNA {
+	population.mu.Lock()
+	defer population.mu.Unlock()
+
+	if population.CacheDirty {
+		populationUpdateBest(population)
 	}
-	return total / float32(len(population.entities))
+	return population.entities[population.bestIndex].Clone()
 }
 
 /**
- * Population: All Phrases
- * Currently unused method, capable of outputting all phrases held by each entity
- * within the current population. Can be called within the evolution loop to help
- * with debugging.
+ * First-Last Match Interaction
+ * A simple rock-paper-scissors style host/parasite interaction: the host wins
+ * (scoring 1) if its first gene matches the parasite's last gene, otherwise the
+ * parasite wins
  */
-func populationAllPhrases(population *Population) string {
-	var everything string
-	var displayLimit int = int(math.Min(float64(len(population.entities)), 50))
-
-	for i := 0; i < displayLimit; i++ {
-		everything += dnaExtractPhrase(&population.entities[i]) + "\n"
+func FirstLastMatchInteraction(host, parasite *DNA) (hostFitness, parasiteFitness float32) {
+	if len(host.genes) > 0 && len(parasite.genes) > 0 && host.genes[0] == parasite.genes[len(parasite.genes)-1] {
+		return 1, 0
 	}
-
-	return everything
+	return 0, 1
 }
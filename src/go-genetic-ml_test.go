@@ -0,0 +1,65 @@
+/**
+ * go-genetic-ml: Tests
+ *
+ * Property tests of the population's bookkeeping, and an integration test of
+ * the full evolution loop
+ *
+ * @copyright Copyright (C) 2018 Daniel J. Wilson <hello@danw.io>
+ * @license GNU GPL v3.0 - See LICENSE
+ */
+package main
+
+import (
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+/**
+ * Phase Timer Averages
+ * Timings recorded over any number of generations average to the recorded
+ * durations
+ */
+func TestPhaseTimerAverages(t *testing.T) {
+	var averages = func(generations uint8, selection, generate, fitness uint32) bool {
+		var timer PhaseTimer
+		for i := 0; i <= int(generations); i++ {
+			// At least a nanosecond per phase, as any real phase takes
+			timer.record(time.Duration(selection)+1, time.Duration(generate)+1, time.Duration(fitness)+1)
+		}
+
+		var average = timer.AveragePerGeneration()
+		return average.Selection == time.Duration(selection)+1 && average.Generate == time.Duration(generate)+1 && average.Fitness == time.Duration(fitness)+1
+	}
+
+	if err := quick.Check(averages, nil); err != nil {
+		t.Error("recorded timings averaged wrongly:", err)
+	}
+}
+
+/**
+ * Full Evolution Loop
+ * Runs the full setup and evolution loop on a short target with a fixed seed,
+ * expecting the solution within 2000 generations. Skipped with -short.
+ */
+func TestFullEvolutionLoop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the full evolution loop in short mode")
+	}
+
+	// Use a fixed seed so the test is repeatable
+	var population, err = NewPopulation(Config{Target: "Hello!", MaxPop: 100, MutationRate: 0.01, CrossoverRate: 1.0, Seed: 42})
+	if err != nil {
+		t.Fatal("creating the population failed:", err)
+	}
+
+	for population.completed == false && population.generations < 2000 {
+		if err := evolve(population); err != nil {
+			t.Fatal("evolving generation", population.generations, "failed:", err)
+		}
+	}
+
+	if !population.completed {
+		t.Error("unsolved after", population.generations, "generations")
+	}
+}
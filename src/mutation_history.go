@@ -0,0 +1,122 @@
+/**
+ * go-genetic-ml: Mutation History
+ *
+ * Optional auditing of the mutations made while breeding a population
+ *
+ * @copyright Copyright (C) 2018 Daniel J. Wilson <hello@danw.io>
+ * @license GNU GPL v3.0 - See LICENSE
+ */
+package main
+
+import (
+	"sync"
+)
+
+/**
+ * Mutation Event
+ * A single gene changed by mutation, in the child bred into the given
+ * generation at the given entity index (or -1 where the child was not bred
+ * straight into an entity slot)
+ */
+type MutationEvent struct {
+	Generation  int
+	EntityIndex int
+	GenePos     int
+	OldRune     rune
+	NewRune     rune
+}
+
+/**
+ * Mutation Audit
+ * Records every mutation made while breeding a population with the config's
+ * AuditMutations set, in the order they were made. Safe for concurrent use.
+ */
+type MutationAudit struct {
+	Events []MutationEvent
+	mu     sync.Mutex
+}
+
+/**
+ * Mutation Audit: Record
+ * Appends the event to the audit
+ */
+func (a *MutationAudit) Record(event MutationEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.Events = append(a.Events, event)
+}
+
+/**
+ * Mutation Audit: Events For Gene
+ * Returns the events which mutated the given gene position, in order
+ */
+func (a *MutationAudit) EventsForGene(pos int) []MutationEvent {
+	return a.filter(func(event MutationEvent) bool {
+		return event.GenePos == pos
+	})
+}
+
+/**
+ * Mutation Audit: Events For Generation
+ * Returns the events made breeding the given generation, in order
+ */
+func (a *MutationAudit) EventsForGeneration(g int) []MutationEvent {
+	return a.filter(func(event MutationEvent) bool {
+		return event.Generation == g
+	})
+}
+
+/**
+ * Mutation Audit: Filter
+ * Returns the events matching the predicate, in order
+ */
+func (a *MutationAudit) filter(pred func(MutationEvent) bool) []MutationEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var events = []MutationEvent{}
+	for _, event := range a.Events {
+		if pred(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+/**
+ * Mutation Audit: Recorder
+ * Returns a function recording mutations of a child bred into the given
+ * generation, for dnaMutateRecording
+ */
+func (a *MutationAudit) recorder(generation int) func(pos int, oldRune, newRune rune) {
+	return func(pos int, oldRune, newRune rune) {
+		a.Record(MutationEvent{Generation: generation, EntityIndex: -1, GenePos: pos, OldRune: oldRune, NewRune: newRune})
+	}
+}
+
+/**
+ * Mutation Audit: Count
+ * Returns the number of events recorded so far
+ */
+func (a *MutationAudit) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.Events)
+}
+
+/**
+ * Mutation Audit: Assign Entity
+ * Sets the entity index of every event recorded since the audit held from
+ * events, once the child they mutated has been placed at that index
+ */
+func (a *MutationAudit) assignEntity(from, index int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := from; i < len(a.Events); i++ {
+		a.Events[i].EntityIndex = index
+	}
+}